@@ -10,6 +10,8 @@ import (
 	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Config holds the configuration for the Census API client
@@ -19,12 +21,18 @@ type Config struct {
 	BaseURL              string
 	Region               string
 	HTTPClient           *http.Client
+
+	// Retry configuration - MaxRetries of 0 disables retries
+	MaxRetries   int
+	RetryWaitMin time.Duration // initial backoff, doubled on each attempt
+	RetryMaxWait time.Duration // upper bound for backoff and Retry-After waits
 }
 
 // Client represents a Census API client
 type Client struct {
-	config     *Config
-	httpClient *http.Client
+	config          *Config
+	httpClient      *http.Client
+	workspaceTokens *workspaceTokenCache
 }
 
 // NewClient creates a new Census API client
@@ -44,26 +52,23 @@ func NewClient(config *Config) (*Client, error) {
 		}
 	}
 
+	if config.MaxRetries < 0 {
+		return nil, fmt.Errorf("max retries cannot be negative")
+	}
+	if config.RetryWaitMin <= 0 {
+		config.RetryWaitMin = DefaultRetryWaitMin
+	}
+	if config.RetryMaxWait <= 0 {
+		config.RetryMaxWait = DefaultRetryMaxWait
+	}
+
 	return &Client{
-		config:     config,
-		httpClient: httpClient,
+		config:          config,
+		httpClient:      httpClient,
+		workspaceTokens: newWorkspaceTokenCache(),
 	}, nil
 }
 
-// APIError represents an error response from the Census API
-type APIError struct {
-	StatusCode int    `json:"status"`
-	Message    string `json:"message,omitempty"`
-	Status     string `json:"status_text,omitempty"`
-}
-
-func (e *APIError) Error() string {
-	if e.Message != "" {
-		return fmt.Sprintf("Census API error (status %d): %s", e.StatusCode, e.Message)
-	}
-	return fmt.Sprintf("Census API error (status %d)", e.StatusCode)
-}
-
 // PaginationInfo holds pagination information from API responses
 type PaginationInfo struct {
 	TotalRecords int  `json:"total_records"`
@@ -86,27 +91,19 @@ func (c *Client) makeRequest(ctx context.Context, method, path string, body inte
 	return c.makeRequestWithToken(ctx, method, path, body, tokenType, "")
 }
 
-// makeRequestWithToken performs an HTTP request to the Census API with a specific token
+// makeRequestWithToken performs an HTTP request to the Census API with a specific token.
+// Rate limited requests, and server errors or dropped connections on idempotent methods,
+// are retried with exponential backoff up to the configured MaxRetries.
 func (c *Client) makeRequestWithToken(ctx context.Context, method, path string, body interface{}, tokenType TokenType, specificToken string) (*http.Response, error) {
-	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewReader(jsonBody)
 	}
 
-	fullURL := c.config.BaseURL + path
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "terraform-provider-census")
-
 	// Set authentication based on token type and availability
 	token := ""
 	if specificToken != "" {
@@ -128,9 +125,80 @@ func (c *Client) makeRequestWithToken(ctx context.Context, method, path string,
 		return nil, fmt.Errorf("required token not provided for token type: %v", tokenType)
 	}
 
+	// The request is built with the logging context so handleResponse can log against it
+	ctx = c.requestLogContext(ctx, method, path, token)
+	if jsonBody != nil {
+		tflog.SubsystemTrace(ctx, LogSubsystem, "Census API request body", map[string]interface{}{
+			"http.request_body": RedactBody(jsonBody),
+		})
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, method, path, jsonBody, token)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Sending Census API request", map[string]interface{}{
+			"attempt": attempt + 1,
+		})
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			tflog.SubsystemDebug(ctx, LogSubsystem, "Census API request failed", map[string]interface{}{
+				"error":       err.Error(),
+				"duration_ms": time.Since(start).Milliseconds(),
+			})
+		} else {
+			tflog.SubsystemDebug(ctx, LogSubsystem, "Received Census API response", map[string]interface{}{
+				"http.status_code": resp.StatusCode,
+				"duration_ms":      time.Since(start).Milliseconds(),
+			})
+		}
+
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && specificToken != "" {
+			// The workspace key was revoked or rotated - make sure it is fetched again next time
+			tflog.SubsystemDebug(ctx, LogSubsystem, "Discarding cached workspace API key rejected by the API")
+			c.workspaceTokens.invalidateToken(specificToken)
+		}
+		if attempt >= c.config.MaxRetries || !c.shouldRetry(ctx, method, resp, err) {
+			return resp, err
+		}
+
+		wait := c.retryWait(attempt, resp)
+		drainBody(resp)
+
+		tflog.SubsystemWarn(ctx, LogSubsystem, "Retrying Census API request", map[string]interface{}{
+			"attempt":   attempt + 1,
+			"wait":      wait.String(),
+			"max_tries": c.config.MaxRetries + 1,
+		})
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// newRequest builds a single HTTP request; it is called once per attempt so the body can be resent
+func (c *Client) newRequest(ctx context.Context, method, path string, jsonBody []byte, token string) (*http.Request, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	fullURL := c.config.BaseURL + path
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform-provider-census")
 	req.Header.Set("Authorization", "Bearer "+token)
 
-	return c.httpClient.Do(req)
+	return req, nil
 }
 
 // TokenType represents the type of authentication token to use
@@ -150,17 +218,14 @@ func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if resp.StatusCode >= 400 {
-		var apiErr APIError
-		apiErr.StatusCode = resp.StatusCode
-
-		// Try to decode JSON error response
-		if json.Unmarshal(body, &apiErr) != nil {
-			// If JSON decode fails, use raw body as message
-			apiErr.Message = string(body)
-		}
+	if resp.Request != nil {
+		tflog.SubsystemTrace(resp.Request.Context(), LogSubsystem, "Census API response body", map[string]interface{}{
+			"http.response_body": RedactBody(body),
+		})
+	}
 
-		return &apiErr
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp.StatusCode, body)
 	}
 
 	if result != nil && len(body) > 0 {
@@ -172,23 +237,19 @@ func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
 	return nil
 }
 
-// buildURL constructs a URL with query parameters
+// buildURL constructs a request path with query parameters. The result is relative to
+// the configured base URL, which is prepended when the request is made.
 func (c *Client) buildURL(path string, params map[string]string) string {
-	fullURL := c.config.BaseURL + path
-
 	if len(params) == 0 {
-		return fullURL
+		return path
 	}
 
-	u, _ := url.Parse(fullURL)
-	q := u.Query()
-
+	q := url.Values{}
 	for key, value := range params {
 		q.Set(key, value)
 	}
 
-	u.RawQuery = q.Encode()
-	return u.String()
+	return path + "?" + q.Encode()
 }
 
 // ListOptions represents options for list operations
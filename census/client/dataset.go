@@ -133,13 +133,8 @@ func (c *Client) DeleteDatasetWithToken(ctx context.Context, id int, workspaceTo
 		return fmt.Errorf("failed to make delete dataset request: %w", err)
 	}
 
-	// For DELETE, we expect either 200 or 204
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		var apiErr APIError
-		if err := c.handleResponse(resp, &apiErr); err != nil {
-			return fmt.Errorf("failed to delete dataset: %w", err)
-		}
-		return &apiErr
+	if err := c.handleResponse(resp, nil); err != nil {
+		return fmt.Errorf("failed to delete dataset: %w", err)
 	}
 
 	return nil
@@ -152,16 +147,40 @@ func (c *Client) ListDatasets(ctx context.Context) ([]Dataset, error) {
 
 // ListDatasetsWithToken lists all datasets in a workspace using a workspace token
 func (c *Client) ListDatasetsWithToken(ctx context.Context, workspaceToken string) ([]Dataset, error) {
+	return c.ListAllDatasetsWithToken(ctx, nil, workspaceToken)
+}
+
+// ListDatasetsPageWithToken retrieves a single page of SQL datasets using a workspace token
+func (c *Client) ListDatasetsPageWithToken(ctx context.Context, opts *ListOptions, workspaceToken string) ([]Dataset, *PaginationInfo, error) {
+	params := make(map[string]string)
+	if opts != nil {
+		params = opts.ToParams()
+	}
 	// Filter by SQL type as per OpenAPI spec
-	resp, err := c.makeRequestWithToken(ctx, http.MethodGet, "/datasets?type=sql", nil, TokenTypeWorkspace, workspaceToken)
+	params["type"] = "sql"
+
+	fullURL := c.buildURL("/datasets", params)
+	resp, err := c.makeRequestWithToken(ctx, http.MethodGet, fullURL, nil, TokenTypeWorkspace, workspaceToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make list datasets request: %w", err)
+		return nil, nil, fmt.Errorf("failed to make list datasets request: %w", err)
 	}
 
 	var result DatasetListResponse
 	if err := c.handleResponse(resp, &result); err != nil {
-		return nil, fmt.Errorf("failed to list datasets: %w", err)
+		return nil, nil, fmt.Errorf("failed to list datasets: %w", err)
 	}
 
-	return result.Data, nil
+	return result.Data, &result.Pagination, nil
+}
+
+// ListAllDatasetsWithToken retrieves every SQL dataset across all pages using a workspace token
+func (c *Client) ListAllDatasetsWithToken(ctx context.Context, opts *ListAllOptions, workspaceToken string) ([]Dataset, error) {
+	datasets, err := ListAllPages(ctx, opts, func(ctx context.Context, pageOpts *ListOptions) ([]Dataset, *PaginationInfo, error) {
+		return c.ListDatasetsPageWithToken(ctx, pageOpts, workspaceToken)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all datasets: %w", err)
+	}
+
+	return datasets, nil
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -209,6 +210,23 @@ func (c *Client) ListDestinationsWithToken(ctx context.Context, opts *ListOption
 	return result.Data, &result.Pagination, nil
 }
 
+// ListAllDestinations retrieves every destination across all pages
+func (c *Client) ListAllDestinations(ctx context.Context, opts *ListAllOptions) ([]Destination, error) {
+	return c.ListAllDestinationsWithToken(ctx, opts, "")
+}
+
+// ListAllDestinationsWithToken retrieves every destination across all pages using a specific workspace token
+func (c *Client) ListAllDestinationsWithToken(ctx context.Context, opts *ListAllOptions, workspaceToken string) ([]Destination, error) {
+	destinations, err := ListAllPages(ctx, opts, func(ctx context.Context, pageOpts *ListOptions) ([]Destination, *PaginationInfo, error) {
+		return c.ListDestinationsWithToken(ctx, pageOpts, workspaceToken)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all destinations: %w", err)
+	}
+
+	return destinations, nil
+}
+
 // GetDestinationObjects retrieves objects for a destination
 func (c *Client) GetDestinationObjects(ctx context.Context, destinationID int) ([]DestinationObject, error) {
 	return c.GetDestinationObjectsWithToken(ctx, destinationID, "")
@@ -354,19 +372,37 @@ type ConnectorsResponse struct {
 	Data       []Connector    `json:"data"`
 }
 
-// GetConnectors retrieves all available connector types and their field requirements
+// GetConnectors retrieves all available connector types and their field requirements across every page
 func (c *Client) GetConnectors(ctx context.Context, workspaceToken string) ([]Connector, error) {
-	resp, err := c.makeRequestWithToken(ctx, http.MethodGet, "/connectors", nil, TokenTypeWorkspace, workspaceToken)
+	connectors, err := ListAllPages(ctx, nil, func(ctx context.Context, pageOpts *ListOptions) ([]Connector, *PaginationInfo, error) {
+		return c.ListConnectorsWithToken(ctx, pageOpts, workspaceToken)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connectors: %w", err)
+	}
+
+	return connectors, nil
+}
+
+// ListConnectorsWithToken retrieves a single page of connector types using a specific workspace token
+func (c *Client) ListConnectorsWithToken(ctx context.Context, opts *ListOptions, workspaceToken string) ([]Connector, *PaginationInfo, error) {
+	params := make(map[string]string)
+	if opts != nil {
+		params = opts.ToParams()
+	}
+
+	fullURL := c.buildURL("/connectors", params)
+	resp, err := c.makeRequestWithToken(ctx, http.MethodGet, fullURL, nil, TokenTypeWorkspace, workspaceToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make get connectors request: %w", err)
+		return nil, nil, fmt.Errorf("failed to make get connectors request: %w", err)
 	}
 
 	var result ConnectorsResponse
 	if err := c.handleResponse(resp, &result); err != nil {
-		return nil, fmt.Errorf("failed to get connectors: %w", err)
+		return nil, nil, fmt.Errorf("failed to list connectors: %w", err)
 	}
 
-	return result.Data, nil
+	return result.Data, &result.Pagination, nil
 }
 
 // ValidateDestinationCredentials validates destination credentials against the connector requirements
@@ -374,7 +410,7 @@ func (c *Client) ValidateDestinationCredentials(ctx context.Context, destination
 	connectors, err := c.GetConnectors(ctx, workspaceToken)
 	if err != nil {
 		// Check if it's a 404 error (endpoint not found) - skip validation gracefully
-		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == 404 {
+		if errors.Is(err, ErrNotFound) {
 			// The /connectors endpoint might not be available, skip validation
 			return nil
 		}
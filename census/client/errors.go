@@ -0,0 +1,211 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors matched by errors.Is against any error returned by the client
+var (
+	ErrNotFound     = errors.New("census: resource not found")
+	ErrUnauthorized = errors.New("census: unauthorized")
+	ErrForbidden    = errors.New("census: forbidden")
+	ErrRateLimited  = errors.New("census: rate limited")
+	ErrConflict     = errors.New("census: conflict")
+)
+
+// APIError represents an error response from the Census API
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"message,omitempty"`
+	Status     string `json:"status_text,omitempty"`
+
+	// Body is the raw response body, kept for debugging when the message alone is not enough
+	Body string `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("Census API error (status %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("Census API error (status %d)", e.StatusCode)
+}
+
+// Is maps the HTTP status code onto the client's sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// ValidationError is returned when the API rejects a request because of invalid fields.
+// FieldErrors maps the API's field name (such as "label" or "connection.credentials.password")
+// to its messages. It unwraps to the underlying *APIError.
+type ValidationError struct {
+	*APIError
+	FieldErrors map[string][]string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.FieldErrors) == 0 {
+		return e.APIError.Error()
+	}
+
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	details := make([]string, 0, len(fields))
+	for _, field := range fields {
+		details = append(details, fmt.Sprintf("%s %s", field, strings.Join(e.FieldErrors[field], ", ")))
+	}
+	return fmt.Sprintf("%s (%s)", e.APIError.Error(), strings.Join(details, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.APIError
+}
+
+// apiErrorBody is the error envelope returned by the Census API. The errors member has been
+// seen both as a map of field names to messages and as a list, so it is decoded separately.
+type apiErrorBody struct {
+	Status  interface{}     `json:"status"`
+	Message string          `json:"message"`
+	Error   string          `json:"error"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+// newAPIError builds the error for a failed response, returning a *ValidationError when the
+// body carries per-field messages or the status is 422
+func newAPIError(statusCode int, body []byte) error {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+
+	var parsed apiErrorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		// Not a JSON error envelope, use raw body as message
+		apiErr.Message = strings.TrimSpace(string(body))
+		return apiErr
+	}
+
+	apiErr.Message = parsed.Message
+	if apiErr.Message == "" {
+		apiErr.Message = parsed.Error
+	}
+	if status, ok := parsed.Status.(string); ok {
+		apiErr.Status = status
+	}
+
+	fieldErrors, general := parseFieldErrors(parsed.Errors)
+	if apiErr.Message == "" && len(general) > 0 {
+		apiErr.Message = strings.Join(general, "; ")
+	}
+
+	if len(fieldErrors) > 0 || statusCode == http.StatusUnprocessableEntity {
+		return &ValidationError{APIError: apiErr, FieldErrors: fieldErrors}
+	}
+	return apiErr
+}
+
+// parseFieldErrors decodes the errors member of an error response into per-field messages and
+// messages that are not tied to a field
+func parseFieldErrors(raw json.RawMessage) (map[string][]string, []string) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	fieldErrors := make(map[string][]string)
+	var general []string
+
+	// {"label": ["can't be blank"], "connection": {"credentials": {"password": "is required"}}}
+	var byField map[string]interface{}
+	if json.Unmarshal(raw, &byField) == nil {
+		addFieldErrors(fieldErrors, "", byField)
+		return fieldErrors, nil
+	}
+
+	// ["Label can't be blank", {"field": "name", "message": "is taken"}]
+	var list []interface{}
+	if json.Unmarshal(raw, &list) == nil {
+		for _, item := range list {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				general = append(general, errorMessages(item)...)
+				continue
+			}
+
+			field, _ := entry["field"].(string)
+			if field == "" {
+				field, _ = entry["attribute"].(string)
+			}
+			messages := errorMessages(entry["message"])
+			if len(messages) == 0 {
+				messages = errorMessages(entry["messages"])
+			}
+
+			if field == "" {
+				general = append(general, messages...)
+			} else {
+				fieldErrors[field] = append(fieldErrors[field], messages...)
+			}
+		}
+		return fieldErrors, general
+	}
+
+	// "Something went wrong"
+	var message string
+	if json.Unmarshal(raw, &message) == nil && message != "" {
+		return nil, []string{message}
+	}
+	return nil, nil
+}
+
+// addFieldErrors flattens nested field error maps into dotted field names
+func addFieldErrors(fieldErrors map[string][]string, prefix string, byField map[string]interface{}) {
+	for field, value := range byField {
+		if prefix != "" {
+			field = prefix + "." + field
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			addFieldErrors(fieldErrors, field, nested)
+			continue
+		}
+		fieldErrors[field] = append(fieldErrors[field], errorMessages(value)...)
+	}
+}
+
+// errorMessages flattens a decoded JSON message value into strings
+func errorMessages(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []interface{}:
+		var messages []string
+		for _, item := range v {
+			messages = append(messages, errorMessages(item)...)
+		}
+		return messages
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
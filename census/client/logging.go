@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// LogSubsystem is the tflog subsystem used for Census API requests
+	LogSubsystem = "census_client"
+	// LogLevelEnvVar sets the log level of the census_client subsystem independently of TF_LOG_PROVIDER
+	LogLevelEnvVar = "TF_LOG_PROVIDER_CENSUS_CLIENT"
+
+	maskedValue = "***"
+)
+
+// sensitiveKeyPattern matches JSON keys whose values are credentials and must never be logged
+var sensitiveKeyPattern = regexp.MustCompile(`(?i)(password|passphrase|secret|token|api_?key|private_?key|access_?key|credential|authorization|service_account)`)
+
+// requestLogContext returns a context with a census_client subsystem logger for a single API request.
+// Root fields set by the provider (such as workspace_id and sync_id) are carried over, and the
+// tokens used by the client are masked wherever they appear in log output.
+func (c *Client) requestLogContext(ctx context.Context, method, path, token string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv(LogLevelEnvVar), tflog.WithRootFields())
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http.method", method)
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http.path", path)
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, "Authorization", "authorization")
+
+	var secrets []string
+	for _, secret := range []string{token, c.config.PersonalAccessToken, c.config.WorkspaceAccessToken} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	if len(secrets) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, secrets...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, LogSubsystem, secrets...)
+	}
+
+	return ctx
+}
+
+// RedactBody returns a JSON request or response body suitable for logging or saving, with the values
+// of credential-like keys replaced at any depth. Bodies that are not JSON are omitted.
+func RedactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "<non-JSON body omitted>"
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return "<body omitted>"
+	}
+	return string(redacted)
+}
+
+// redactValue walks a decoded JSON value and masks values stored under sensitive keys
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if nested != nil && sensitiveKeyPattern.MatchString(key) {
+				v[key] = maskedValue
+				continue
+			}
+			v[key] = redactValue(nested)
+		}
+		return v
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+		return v
+	default:
+		return v
+	}
+}
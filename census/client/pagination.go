@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultListAllPerPage is the page size used by the ListAll* methods when none is given
+const DefaultListAllPerPage = 100
+
+// PageFetcher retrieves a single page of a paginated list endpoint
+type PageFetcher[T any] func(ctx context.Context, opts *ListOptions) ([]T, *PaginationInfo, error)
+
+// ListAllOptions controls how ListAllPages walks a paginated endpoint
+type ListAllOptions struct {
+	PerPage int
+	Order   string
+
+	// Concurrency is the maximum number of pages fetched at the same time once the
+	// first page has reported the last page number. Values below 2 fetch pages one by one.
+	Concurrency int
+}
+
+// ListAllPages fetches every page of a paginated endpoint and returns the combined results in page order.
+// The first page is always fetched on its own; later pages follow PaginationInfo.NextPage, or are
+// fetched concurrently up to opts.Concurrency when the total number of pages is known.
+func ListAllPages[T any](ctx context.Context, opts *ListAllOptions, fetch PageFetcher[T]) ([]T, error) {
+	if opts == nil {
+		opts = &ListAllOptions{}
+	}
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultListAllPerPage
+	}
+	pageOpts := func(page int) *ListOptions {
+		return &ListOptions{Page: page, PerPage: perPage, Order: opts.Order}
+	}
+
+	items, pagination, err := fetch(ctx, pageOpts(1))
+	if err != nil {
+		return nil, err
+	}
+	if pagination == nil || pagination.NextPage == nil {
+		return items, nil
+	}
+
+	if opts.Concurrency > 1 && pagination.LastPage > pagination.Page {
+		rest, err := fetchPagesConcurrently(ctx, *pagination.NextPage, pagination.LastPage, opts.Concurrency, pageOpts, fetch)
+		if err != nil {
+			return nil, err
+		}
+		return append(items, rest...), nil
+	}
+
+	current := pagination.Page
+	next := pagination.NextPage
+	for next != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if *next <= current {
+			return nil, fmt.Errorf("pagination did not advance past page %d", current)
+		}
+
+		page := *next
+		pageItems, pagination, err := fetch(ctx, pageOpts(page))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+		items = append(items, pageItems...)
+
+		current = page
+		next = nil
+		if pagination != nil {
+			next = pagination.NextPage
+		}
+	}
+
+	return items, nil
+}
+
+// fetchPagesConcurrently fetches pages first through last with at most concurrency requests in
+// flight, stopping all outstanding requests on the first error
+func fetchPagesConcurrently[T any](ctx context.Context, first, last, concurrency int, pageOpts func(int) *ListOptions, fetch PageFetcher[T]) ([]T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([][]T, last-first+1)
+	sem := make(chan struct{}, concurrency)
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	setErr := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for page := first; page <= last; page++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(page int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			pageItems, _, err := fetch(ctx, pageOpts(page))
+			if err != nil {
+				setErr(fmt.Errorf("failed to fetch page %d: %w", page, err))
+				return
+			}
+			pages[page-first] = pageItems
+		}(page)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// Surface cancellation of the parent context when no request failed outright
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var items []T
+	for _, pageItems := range pages {
+		items = append(items, pageItems...)
+	}
+	return items, nil
+}
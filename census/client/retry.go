@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries used by the provider when max_retries is not set
+	DefaultMaxRetries = 3
+	// DefaultRetryWaitMin is the initial backoff between retries
+	DefaultRetryWaitMin = 1 * time.Second
+	// DefaultRetryMaxWait is the maximum time to wait between retries
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// idempotentMethods are the HTTP methods that are safe to resend after a
+// server error or a dropped connection
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+}
+
+// shouldRetry reports whether a request should be sent again given the outcome of the previous attempt
+func (c *Client) shouldRetry(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		// Transport errors (connection reset, EOF, timeouts) may have reached the server,
+		// so only replay requests that are safe to send twice
+		return idempotentMethods[method]
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// Rate limited requests were rejected before being processed
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotentMethods[method]
+	}
+
+	return false
+}
+
+// retryWait returns how long to wait before the given retry attempt (starting at 0)
+func (c *Client) retryWait(attempt int, resp *http.Response) time.Duration {
+	maxWait := c.config.RetryMaxWait
+
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > maxWait {
+				return maxWait
+			}
+			return wait
+		}
+	}
+
+	// Exponential backoff with jitter: a random duration in [base/2, base]
+	base := float64(c.config.RetryWaitMin) * math.Pow(2, float64(attempt))
+	if base > float64(maxWait) {
+		base = float64(maxWait)
+	}
+	half := base / 2
+	return time.Duration(half + rand.Float64()*half)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// drainBody discards and closes a response body so the underlying connection can be reused
+func drainBody(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
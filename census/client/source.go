@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -66,6 +67,34 @@ type SourceObjectsResponse struct {
 	Data   []SourceObject `json:"data"`
 }
 
+// SourceTable represents a table in a source warehouse
+type SourceTable struct {
+	ID           int    `json:"id"`
+	TableCatalog string `json:"table_catalog,omitempty"`
+	TableSchema  string `json:"table_schema,omitempty"`
+	TableName    string `json:"table_name"`
+}
+
+// SourceTableListResponse represents a paginated source table list response
+type SourceTableListResponse struct {
+	Status     string         `json:"status"`
+	Pagination PaginationInfo `json:"pagination"`
+	Data       []SourceTable  `json:"data"`
+}
+
+// SourceColumn represents a column of a source table or dataset
+type SourceColumn struct {
+	Name     string `json:"name"`
+	DataType string `json:"data_type"`
+}
+
+// SourceColumnListResponse represents a paginated source column list response
+type SourceColumnListResponse struct {
+	Status     string         `json:"status"`
+	Pagination PaginationInfo `json:"pagination"`
+	Data       []SourceColumn `json:"data"`
+}
+
 // ConnectLink represents a connection link for OAuth/reauth
 type ConnectLink struct {
 	URL       string    `json:"url"`
@@ -198,6 +227,23 @@ func (c *Client) ListSourcesWithToken(ctx context.Context, opts *ListOptions, wo
 	return result.Data, &result.Pagination, nil
 }
 
+// ListAllSources retrieves every source across all pages
+func (c *Client) ListAllSources(ctx context.Context, opts *ListAllOptions) ([]Source, error) {
+	return c.ListAllSourcesWithToken(ctx, opts, "")
+}
+
+// ListAllSourcesWithToken retrieves every source across all pages using a specific workspace token
+func (c *Client) ListAllSourcesWithToken(ctx context.Context, opts *ListAllOptions, workspaceToken string) ([]Source, error) {
+	sources, err := ListAllPages(ctx, opts, func(ctx context.Context, pageOpts *ListOptions) ([]Source, *PaginationInfo, error) {
+		return c.ListSourcesWithToken(ctx, pageOpts, workspaceToken)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all sources: %w", err)
+	}
+
+	return sources, nil
+}
+
 // GetSourceObjects retrieves objects (tables, models, etc.) for a source
 func (c *Client) GetSourceObjects(ctx context.Context, sourceID int) ([]SourceObject, error) {
 	return c.GetSourceObjectsWithToken(ctx, sourceID, "")
@@ -219,6 +265,87 @@ func (c *Client) GetSourceObjectsWithToken(ctx context.Context, sourceID int, wo
 	return result.Data, nil
 }
 
+// ListSourceTablesWithToken retrieves a page of the tables in a source using a specific workspace token
+func (c *Client) ListSourceTablesWithToken(ctx context.Context, sourceID int, opts *ListOptions, workspaceToken string) ([]SourceTable, *PaginationInfo, error) {
+	params := make(map[string]string)
+	if opts != nil {
+		params = opts.ToParams()
+	}
+
+	fullURL := c.buildURL(fmt.Sprintf("/sources/%d/tables", sourceID), params)
+	resp, err := c.makeRequestWithToken(ctx, http.MethodGet, fullURL, nil, TokenTypeWorkspace, workspaceToken)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make list source tables request: %w", err)
+	}
+
+	var result SourceTableListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, nil, fmt.Errorf("failed to list source tables: %w", err)
+	}
+
+	return result.Data, &result.Pagination, nil
+}
+
+// ListSourceTableColumnsWithToken retrieves a page of the columns of a source table using a specific workspace token
+func (c *Client) ListSourceTableColumnsWithToken(ctx context.Context, sourceID, tableID int, opts *ListOptions, workspaceToken string) ([]SourceColumn, *PaginationInfo, error) {
+	params := make(map[string]string)
+	if opts != nil {
+		params = opts.ToParams()
+	}
+
+	fullURL := c.buildURL(fmt.Sprintf("/sources/%d/tables/%d/columns", sourceID, tableID), params)
+	resp, err := c.makeRequestWithToken(ctx, http.MethodGet, fullURL, nil, TokenTypeWorkspace, workspaceToken)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make list source table columns request: %w", err)
+	}
+
+	var result SourceColumnListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, nil, fmt.Errorf("failed to list source table columns: %w", err)
+	}
+
+	return result.Data, &result.Pagination, nil
+}
+
+// GetSourceTableColumnsWithToken finds a table by catalog, schema and name and returns all of its columns.
+// Empty catalog or schema values match any table. The error wraps ErrNotFound when no table matches.
+func (c *Client) GetSourceTableColumnsWithToken(ctx context.Context, sourceID int, tableCatalog, tableSchema, tableName, workspaceToken string) ([]SourceColumn, error) {
+	tables, err := ListAllPages(ctx, nil, func(ctx context.Context, pageOpts *ListOptions) ([]SourceTable, *PaginationInfo, error) {
+		return c.ListSourceTablesWithToken(ctx, sourceID, pageOpts, workspaceToken)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all source tables: %w", err)
+	}
+
+	var table *SourceTable
+	for i := range tables {
+		t := &tables[i]
+		if !strings.EqualFold(t.TableName, tableName) {
+			continue
+		}
+		if tableSchema != "" && !strings.EqualFold(t.TableSchema, tableSchema) {
+			continue
+		}
+		if tableCatalog != "" && !strings.EqualFold(t.TableCatalog, tableCatalog) {
+			continue
+		}
+		table = t
+		break
+	}
+	if table == nil {
+		return nil, fmt.Errorf("table %q not found in source %d: %w", tableName, sourceID, ErrNotFound)
+	}
+
+	columns, err := ListAllPages(ctx, nil, func(ctx context.Context, pageOpts *ListOptions) ([]SourceColumn, *PaginationInfo, error) {
+		return c.ListSourceTableColumnsWithToken(ctx, sourceID, table.ID, pageOpts, workspaceToken)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all source table columns: %w", err)
+	}
+
+	return columns, nil
+}
+
 // CreateSourceConnectLink creates a connect link for source reauthorization
 func (c *Client) CreateSourceConnectLink(ctx context.Context, sourceID int) (*ConnectLink, error) {
 	return c.CreateSourceConnectLinkWithToken(ctx, sourceID, "")
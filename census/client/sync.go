@@ -1,12 +1,9 @@
 package client
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"net/http"
-	"os"
 	"time"
 )
 
@@ -114,6 +111,7 @@ type MappingAttributes struct {
 	ArrayField          bool        `json:"array_field,omitempty"`
 	FieldType           string      `json:"field_type,omitempty"`
 	FollowSourceType    bool        `json:"follow_source_type,omitempty"`
+	Operation           string      `json:"operation,omitempty"` // "hash" to hash a column mapping's values before they are synced
 }
 
 // MappingFrom represents the source of a mapping
@@ -216,26 +214,20 @@ type CreateSyncRequest struct {
 	MirrorStrategy string `json:"mirror_strategy,omitempty"` // sync_updates_and_deletes, sync_updates_and_nulls, upload_and_swap
 }
 
-// UpdateSyncRequest represents the request to update a sync
+// UpdateSyncRequest represents the request to update a sync. Unset fields are omitted so that
+// only changed attributes are sent.
 type UpdateSyncRequest struct {
 	Label                 string                 `json:"label,omitempty"`
+	Operation             string                 `json:"operation,omitempty"`
 	SourceAttributes      map[string]interface{} `json:"source_attributes,omitempty"`
 	DestinationAttributes map[string]interface{} `json:"destination_attributes,omitempty"`
-	FieldMappings         []FieldMapping         `json:"field_mappings,omitempty"`
-	SyncKey               []string               `json:"sync_key,omitempty"`
-	SyncMode              string                 `json:"sync_mode,omitempty"`
+	Mappings              []MappingAttributes    `json:"mappings,omitempty"`
 
-	// Schedule fields - Census Management API expects these as flat fields, not nested object
-	ScheduleFrequency string `json:"schedule_frequency,omitempty"`
-	ScheduleDay       *int   `json:"schedule_day,omitempty"`
-	ScheduleHour      *int   `json:"schedule_hour,omitempty"`
-	ScheduleMinute    *int   `json:"schedule_minute,omitempty"`
-	CronExpression    string `json:"cron_expression,omitempty"`
-
-	// Mode - live vs triggered with trigger configurations (replaces schedule fields)
+	// Mode - live vs triggered with trigger configurations
 	Mode *SyncMode `json:"mode,omitempty"`
 
-	Paused bool `json:"paused,omitempty"`
+	// Pointer so that unpausing (false) can be told apart from not changing paused
+	Paused *bool `json:"paused,omitempty"`
 
 	// Field configuration
 	FieldBehavior      string `json:"field_behavior,omitempty"`      // sync_all_properties or specific_properties
@@ -245,20 +237,21 @@ type UpdateSyncRequest struct {
 	// Sync behavior family
 	SyncBehaviorFamily string `json:"sync_behavior_family,omitempty"` // activateEvents or mapRecords
 
-	// Advanced configuration - destination-specific options
-	AdvancedConfiguration map[string]interface{} `json:"advanced_configuration,omitempty"`
+	// Advanced configuration - destination-specific options. Pointer so that clearing it (an empty
+	// object) can be told apart from not changing it
+	AdvancedConfiguration *map[string]interface{} `json:"advanced_configuration,omitempty"`
 
-	// High water mark attribute - timestamp column for append syncs
-	HighWaterMarkAttribute string `json:"high_water_mark_attribute,omitempty"`
+	// High water mark attribute - timestamp column for append syncs. Pointer so that it can be cleared
+	HighWaterMarkAttribute *string `json:"high_water_mark_attribute,omitempty"`
 
 	// Historical sync operation - how first sync handles existing records
 	HistoricalSyncOperation string `json:"historical_sync_operation,omitempty"` // skip_current_records or backfill_all_records
 
-	// Mirror strategy - how mirror syncs keep destination in sync (when operation=mirror)
-	MirrorStrategy string `json:"mirror_strategy,omitempty"` // sync_updates_and_deletes, sync_updates_and_nulls, upload_and_swap
+	// Mirror strategy - how mirror syncs keep destination in sync (when operation=mirror). Pointer so that it can be cleared
+	MirrorStrategy *string `json:"mirror_strategy,omitempty"` // sync_updates_and_deletes, sync_updates_and_nulls, upload_and_swap
 
-	// Alert configuration
-	AlertAttributes []AlertAttribute `json:"alert_attributes,omitempty"`
+	// Alert configuration. Pointer so that removing every alert (an empty list) can be told apart from not changing them
+	AlertAttributes *[]AlertAttribute `json:"alert_attributes,omitempty"`
 }
 
 // SyncResponse represents a single sync response
@@ -322,44 +315,21 @@ func (c *Client) CreateSync(ctx context.Context, req *CreateSyncRequest) (*Sync,
 
 // CreateSyncWithToken creates a new sync using a specific workspace token
 func (c *Client) CreateSyncWithToken(ctx context.Context, req *CreateSyncRequest, workspaceToken string) (*Sync, error) {
-	// Log the request being sent
-	fmt.Printf("[DEBUG] Create sync request: %+v\n", req)
-
 	resp, err := c.makeRequestWithToken(ctx, http.MethodPost, "/syncs", req, TokenTypeWorkspace, workspaceToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make create sync request: %w", err)
 	}
 
-	// Read the raw response body for debugging
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	fmt.Printf("[DEBUG] Create sync raw response: %s\n", string(bodyBytes))
-
-	// Write request and response to debug file
-	debugFile := "/tmp/census_sync_debug.log"
-	debugContent := fmt.Sprintf("=== CREATE SYNC DEBUG ===\n\nREQUEST:\n%+v\n\nRAW RESPONSE:\n%s\n\n", req, string(bodyBytes))
-	os.WriteFile(debugFile, []byte(debugContent), 0644)
-	fmt.Printf("[DEBUG] Debug info written to %s\n", debugFile)
-
-	// Reset the response body so handleResponse can read it
-	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-
 	var result CreateSyncResponse
 	if err := c.handleResponse(resp, &result); err != nil {
 		return nil, fmt.Errorf("failed to create sync: %w", err)
 	}
 
-	fmt.Printf("[DEBUG] Parsed create sync response: %+v\n", result)
-
 	// Create a minimal Sync object from the response
 	sync := &Sync{
 		ID: result.Data.SyncID,
 	}
 
-	fmt.Printf("[DEBUG] Created sync object with ID: %d\n", sync.ID)
 	return sync, nil
 }
 
@@ -451,6 +421,23 @@ func (c *Client) ListSyncsWithToken(ctx context.Context, opts *ListOptions, work
 	return result.Data, &result.Pagination, nil
 }
 
+// ListAllSyncs retrieves every sync across all pages
+func (c *Client) ListAllSyncs(ctx context.Context, opts *ListAllOptions) ([]Sync, error) {
+	return c.ListAllSyncsWithToken(ctx, opts, "")
+}
+
+// ListAllSyncsWithToken retrieves every sync across all pages using a specific workspace token
+func (c *Client) ListAllSyncsWithToken(ctx context.Context, opts *ListAllOptions, workspaceToken string) ([]Sync, error) {
+	syncs, err := ListAllPages(ctx, opts, func(ctx context.Context, pageOpts *ListOptions) ([]Sync, *PaginationInfo, error) {
+		return c.ListSyncsWithToken(ctx, pageOpts, workspaceToken)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all syncs: %w", err)
+	}
+
+	return syncs, nil
+}
+
 // TriggerSync triggers a sync execution
 func (c *Client) TriggerSync(ctx context.Context, syncID int, req *TriggerSyncRequest) (int, error) {
 	return c.TriggerSyncWithToken(ctx, syncID, req, "")
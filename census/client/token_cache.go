@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// workspaceTokenCache holds workspace API keys for the lifetime of a client so
+// that every resource in a provider run shares a single lookup per workspace
+type workspaceTokenCache struct {
+	mu       sync.Mutex
+	tokens   map[int]string
+	inflight map[int]*tokenLookup
+}
+
+// tokenLookup is an in-progress API key request that concurrent callers wait on
+type tokenLookup struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+func newWorkspaceTokenCache() *workspaceTokenCache {
+	return &workspaceTokenCache{
+		tokens:   make(map[int]string),
+		inflight: make(map[int]*tokenLookup),
+	}
+}
+
+// get returns the cached token for a workspace, calling fetch at most once for
+// all concurrent callers when the token is not cached yet
+func (tc *workspaceTokenCache) get(ctx context.Context, workspaceID int, fetch func() (string, error)) (string, error) {
+	tc.mu.Lock()
+	if token, ok := tc.tokens[workspaceID]; ok {
+		tc.mu.Unlock()
+		return token, nil
+	}
+
+	if lookup, ok := tc.inflight[workspaceID]; ok {
+		tc.mu.Unlock()
+		select {
+		case <-lookup.done:
+			return lookup.token, lookup.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	lookup := &tokenLookup{done: make(chan struct{})}
+	tc.inflight[workspaceID] = lookup
+	tc.mu.Unlock()
+
+	lookup.token, lookup.err = fetch()
+
+	tc.mu.Lock()
+	delete(tc.inflight, workspaceID)
+	if lookup.err == nil {
+		tc.tokens[workspaceID] = lookup.token
+	}
+	tc.mu.Unlock()
+	close(lookup.done)
+
+	return lookup.token, lookup.err
+}
+
+// invalidate removes the cached token for a workspace
+func (tc *workspaceTokenCache) invalidate(workspaceID int) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	delete(tc.tokens, workspaceID)
+}
+
+// invalidateToken removes every cache entry holding the given token
+func (tc *workspaceTokenCache) invalidateToken(token string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	for workspaceID, cached := range tc.tokens {
+		if cached == token {
+			delete(tc.tokens, workspaceID)
+		}
+	}
+}
+
+// GetCachedWorkspaceAPIKey returns the API key for a workspace, fetching it with
+// GetWorkspaceAPIKey only when it is not already cached. Concurrent calls for the
+// same workspace share a single request. Cached keys are dropped when the API
+// rejects them with a 401.
+func (c *Client) GetCachedWorkspaceAPIKey(ctx context.Context, workspaceID int) (string, error) {
+	return c.workspaceTokens.get(ctx, workspaceID, func() (string, error) {
+		token, err := c.GetWorkspaceAPIKey(ctx, workspaceID)
+		if err != nil {
+			return "", err
+		}
+		if token == "" {
+			return "", fmt.Errorf("workspace API key is empty for workspace %d", workspaceID)
+		}
+		return token, nil
+	})
+}
+
+// InvalidateWorkspaceAPIKey removes a workspace API key from the cache so the next
+// call to GetCachedWorkspaceAPIKey fetches a fresh one
+func (c *Client) InvalidateWorkspaceAPIKey(workspaceID int) {
+	c.workspaceTokens.invalidate(workspaceID)
+}
@@ -126,6 +126,16 @@ func (c *Client) ListWorkspaces(ctx context.Context, opts *ListOptions) ([]Works
 	return result.Data, &result.Pagination, nil
 }
 
+// ListAllWorkspaces retrieves every workspace across all pages
+func (c *Client) ListAllWorkspaces(ctx context.Context, opts *ListAllOptions) ([]Workspace, error) {
+	workspaces, err := ListAllPages(ctx, opts, c.ListWorkspaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all workspaces: %w", err)
+	}
+
+	return workspaces, nil
+}
+
 // GetAuthenticatedWorkspace retrieves the workspace for the authenticated workspace token
 func (c *Client) GetAuthenticatedWorkspace(ctx context.Context) (*Workspace, error) {
 	return c.GetAuthenticatedWorkspaceWithToken(ctx, "")
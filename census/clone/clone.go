@@ -0,0 +1,377 @@
+// Package clone copies the datasets and syncs of one Census workspace into another, such as when
+// promoting a staging workspace to production. Connection and dataset IDs are translated with a
+// user-supplied mapping, objects that already exist in the target are skipped, and the outcome of
+// every object is returned in a report.
+package clone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/export"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+const (
+	datasetType = "census_dataset"
+	syncType    = "census_sync"
+)
+
+// Mapping translates IDs in the source workspace to IDs in the target workspace. Datasets that are
+// mapped are not cloned; syncs reading them use the mapped dataset instead.
+type Mapping struct {
+	Sources      map[int]int `json:"sources"`
+	Destinations map[int]int `json:"destinations"`
+	Datasets     map[int]int `json:"datasets"`
+}
+
+// Options configures a clone
+type Options struct {
+	SourceWorkspaceID int
+	TargetWorkspaceID int
+	Mapping           Mapping
+
+	// DryRun reports what would be created without creating anything. Objects that do not depend on
+	// other objects the clone would create are still planned, so their validation errors are reported.
+	DryRun bool
+}
+
+// Status is the outcome of cloning one object
+type Status string
+
+const (
+	// StatusCreated means the object was created in the target workspace
+	StatusCreated Status = "created"
+	// StatusWouldCreate means a dry run would have created the object
+	StatusWouldCreate Status = "would_create"
+	// StatusSkipped means the object was not cloned because it is mapped or already exists in the target
+	StatusSkipped Status = "skipped"
+	// StatusFailed means the object could not be cloned; Reason says why
+	StatusFailed Status = "failed"
+)
+
+// Result is the outcome of cloning a dataset or sync
+type Result struct {
+	ResourceType string `json:"resource_type"`
+	SourceID     int    `json:"source_id"`
+	Name         string `json:"name"`
+	Status       Status `json:"status"`
+	TargetID     int    `json:"target_id,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// Report lists the outcome of every dataset and then every sync in the source workspace
+type Report struct {
+	SourceWorkspaceID int      `json:"source_workspace_id"`
+	TargetWorkspaceID int      `json:"target_workspace_id"`
+	DryRun            bool     `json:"dry_run"`
+	Results           []Result `json:"results"`
+}
+
+// Count returns the number of results with status
+func (r *Report) Count(status Status) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// pending is the target ID of an object a dry run would create
+const pending = -1
+
+// cloner clones one workspace. datasets and syncs map source IDs to target IDs as objects are
+// cloned, skipped or mapped, so later objects can refer to them.
+type cloner struct {
+	p      *schema.Provider
+	opts   Options
+	report *Report
+
+	datasets map[int]int
+	syncs    map[int]int
+}
+
+// Clone copies the datasets and syncs of opts.SourceWorkspaceID into opts.TargetWorkspaceID with the
+// configured provider p. Objects that cannot be cloned are reported as failed and do not stop the
+// clone; an error is only returned when the workspaces cannot be read.
+func Clone(ctx context.Context, p *schema.Provider, opts Options) (*Report, error) {
+	apiClient, ok := p.Meta().(*client.Client)
+	if !ok {
+		return nil, fmt.Errorf("provider is not configured")
+	}
+	if opts.SourceWorkspaceID == opts.TargetWorkspaceID {
+		return nil, fmt.Errorf("the source and target workspace are the same: %d", opts.SourceWorkspaceID)
+	}
+
+	source, err := listWorkspace(ctx, apiClient, opts.SourceWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source workspace %d: %w", opts.SourceWorkspaceID, err)
+	}
+	target, err := listWorkspace(ctx, apiClient, opts.TargetWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read target workspace %d: %w", opts.TargetWorkspaceID, err)
+	}
+
+	c := &cloner{
+		p:    p,
+		opts: opts,
+		report: &Report{
+			SourceWorkspaceID: opts.SourceWorkspaceID,
+			TargetWorkspaceID: opts.TargetWorkspaceID,
+			DryRun:            opts.DryRun,
+		},
+		datasets: map[int]int{},
+		syncs:    map[int]int{},
+	}
+
+	sort.Slice(source.datasets, func(i, j int) bool { return source.datasets[i].ID < source.datasets[j].ID })
+	for _, dataset := range source.datasets {
+		c.cloneDataset(ctx, dataset, target)
+	}
+
+	syncs := make(map[int]client.Sync, len(source.syncs))
+	for _, sync := range source.syncs {
+		syncs[sync.ID] = sync
+	}
+	order, err := provider.BuildSyncGraph(source.syncs).TopologicalOrder()
+	if err != nil {
+		// Syncs triggered from a cycle fail because the sync they wait for is not cloned yet
+		order = make([]int, 0, len(source.syncs))
+		for id := range syncs {
+			order = append(order, id)
+		}
+		sort.Ints(order)
+	}
+	for _, id := range order {
+		c.cloneSync(ctx, syncs[id], target)
+	}
+
+	return c.report, nil
+}
+
+// workspaceObjects are the datasets and syncs of a workspace
+type workspaceObjects struct {
+	datasets []client.Dataset
+	syncs    []client.Sync
+}
+
+func listWorkspace(ctx context.Context, apiClient *client.Client, workspaceID int) (*workspaceObjects, error) {
+	token, err := apiClient.GetCachedWorkspaceAPIKey(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace API key: %w", err)
+	}
+	datasets, err := apiClient.ListAllDatasetsWithToken(ctx, nil, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list datasets: %w", err)
+	}
+	syncs, err := apiClient.ListAllSyncsWithToken(ctx, nil, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list syncs: %w", err)
+	}
+	return &workspaceObjects{datasets: datasets, syncs: syncs}, nil
+}
+
+func (c *cloner) cloneDataset(ctx context.Context, dataset client.Dataset, target *workspaceObjects) {
+	result := Result{ResourceType: datasetType, SourceID: dataset.ID, Name: dataset.Name}
+
+	if id, ok := c.opts.Mapping.Datasets[dataset.ID]; ok {
+		c.datasets[dataset.ID] = id
+		c.add(result, StatusSkipped, id, fmt.Sprintf("mapped to dataset %d", id))
+		return
+	}
+	existing := matchingIDs(target.datasets, dataset.Name, func(d client.Dataset) string { return d.Name }, func(d client.Dataset) int { return d.ID })
+	if done := c.skipExisting(result, existing, "name", c.datasets); done {
+		return
+	}
+
+	config, err := c.read(ctx, datasetType, dataset.ID)
+	if err != nil {
+		c.add(result, StatusFailed, 0, err.Error())
+		return
+	}
+	usesPending, err := c.remapDataset(config)
+	if err != nil {
+		c.add(result, StatusFailed, 0, err.Error())
+		return
+	}
+	c.create(ctx, result, datasetType, config, c.datasets, usesPending)
+}
+
+func (c *cloner) cloneSync(ctx context.Context, sync client.Sync, target *workspaceObjects) {
+	result := Result{ResourceType: syncType, SourceID: sync.ID, Name: sync.Label}
+
+	existing := c.existingSyncs(sync, target)
+	if sync.Label == "" && len(existing) > 0 {
+		// Without a label there is nothing to tell a copy of this sync from another sync between the
+		// same connections
+		c.add(result, StatusFailed, 0, fmt.Sprintf("the sync has no label, so target syncs with the same connections (IDs %s) cannot be matched to it", joinIDs(existing)))
+		return
+	}
+	if done := c.skipExisting(result, existing, "label and connections", c.syncs); done {
+		return
+	}
+
+	config, err := c.read(ctx, syncType, sync.ID)
+	if err != nil {
+		c.add(result, StatusFailed, 0, err.Error())
+		return
+	}
+	usesPending, err := c.remapSync(config)
+	if err != nil {
+		c.add(result, StatusFailed, 0, err.Error())
+		return
+	}
+	c.create(ctx, result, syncType, config, c.syncs, usesPending)
+}
+
+// existingSyncs returns the IDs of the syncs in the target workspace that have the label of sync and
+// use the source and destination connections its own connections are mapped to
+func (c *cloner) existingSyncs(sync client.Sync, target *workspaceObjects) []int {
+	sourceID, ok := c.opts.Mapping.Sources[attributeConnectionID(sync.SourceAttributes)]
+	if !ok {
+		return nil
+	}
+	destinationID, ok := c.opts.Mapping.Destinations[attributeConnectionID(sync.DestinationAttributes)]
+	if !ok {
+		return nil
+	}
+
+	var ids []int
+	for _, s := range target.syncs {
+		if s.Label == sync.Label && attributeConnectionID(s.SourceAttributes) == sourceID && attributeConnectionID(s.DestinationAttributes) == destinationID {
+			ids = append(ids, s.ID)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// attributeConnectionID returns the connection_id of sync source or destination attributes from the
+// API, or 0
+func attributeConnectionID(attributes map[string]interface{}) int {
+	switch id := attributes["connection_id"].(type) {
+	case float64:
+		return int(id)
+	case int:
+		return id
+	case string:
+		i, _ := strconv.Atoi(id)
+		return i
+	}
+	return 0
+}
+
+// skipExisting reports an object whose name already exists in the target as skipped and maps it to
+// the existing object, or as failed when several objects in the target have the name
+func (c *cloner) skipExisting(result Result, existing []int, attribute string, ids map[int]int) bool {
+	switch len(existing) {
+	case 0:
+		return false
+	case 1:
+		ids[result.SourceID] = existing[0]
+		c.add(result, StatusSkipped, existing[0], fmt.Sprintf("the target workspace already has a %s with this %s", strings.TrimPrefix(result.ResourceType, "census_"), attribute))
+	default:
+		c.add(result, StatusFailed, 0, fmt.Sprintf("the target workspace has %d %ss with this %s (IDs %s)", len(existing), strings.TrimPrefix(result.ResourceType, "census_"), attribute, joinIDs(existing)))
+	}
+	return true
+}
+
+// read returns the configuration of an object in the source workspace
+func (c *cloner) read(ctx context.Context, resourceType string, id int) (map[string]interface{}, error) {
+	config, err := export.ReadConfig(ctx, c.p, resourceType, c.opts.SourceWorkspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, fmt.Errorf("%s %d was deleted while cloning", strings.TrimPrefix(resourceType, "census_"), id)
+	}
+	return config, nil
+}
+
+// create plans config as a new resource in the target workspace and creates it, recording the new ID
+// in ids. A dry run only plans it, unless it uses objects the dry run would create.
+func (c *cloner) create(ctx context.Context, result Result, resourceType string, config map[string]interface{}, ids map[int]int, usesPending bool) {
+	if usesPending {
+		ids[result.SourceID] = pending
+		c.add(result, StatusWouldCreate, 0, "not validated, because it uses objects this clone would create")
+		return
+	}
+
+	r := c.p.ResourcesMap[resourceType]
+	data, err := json.Marshal(config)
+	if err != nil {
+		c.add(result, StatusFailed, 0, fmt.Sprintf("failed to encode configuration: %v", err))
+		return
+	}
+	value, err := ctyjson.Unmarshal(data, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		c.add(result, StatusFailed, 0, fmt.Sprintf("failed to build configuration: %v", err))
+		return
+	}
+
+	state := &terraform.InstanceState{RawConfig: value}
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigShimmed(value, r.CoreConfigSchema()), c.p.Meta())
+	if err != nil {
+		c.add(result, StatusFailed, 0, fmt.Sprintf("plan failed: %v", err))
+		return
+	}
+	if c.opts.DryRun {
+		ids[result.SourceID] = pending
+		c.add(result, StatusWouldCreate, 0, "")
+		return
+	}
+
+	created, diags := r.Apply(ctx, state, diff, c.p.Meta())
+	if diags.HasError() {
+		reason := diags[0].Summary
+		if diags[0].Detail != "" {
+			reason += ": " + diags[0].Detail
+		}
+		c.add(result, StatusFailed, 0, reason)
+		return
+	}
+	id, err := strconv.Atoi(created.ID)
+	if err != nil {
+		c.add(result, StatusFailed, 0, fmt.Sprintf("created with an unexpected ID %q", created.ID))
+		return
+	}
+	ids[result.SourceID] = id
+	c.add(result, StatusCreated, id, "")
+}
+
+func (c *cloner) add(result Result, status Status, targetID int, reason string) {
+	result.Status = status
+	result.TargetID = targetID
+	result.Reason = reason
+	c.report.Results = append(c.report.Results, result)
+}
+
+func matchingIDs[T any](items []T, name string, nameOf func(T) string, idOf func(T) int) []int {
+	var ids []int
+	for _, item := range items {
+		if nameOf(item) == name {
+			ids = append(ids, idOf(item))
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func joinIDs(ids []int) string {
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, strconv.Itoa(id))
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,108 @@
+package clone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+// Command is the name of the provider binary's clone subcommand
+const Command = "clone"
+
+// Main runs the clone subcommand with args and returns its exit code, which is 1 when any object
+// failed to clone. The provider is configured from the same environment variables as in Terraform,
+// such as CENSUS_PERSONAL_ACCESS_TOKEN.
+func Main(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	from := flags.Int("from", 0, "ID of the workspace to clone datasets and syncs from")
+	to := flags.Int("to", 0, "ID of the workspace to create them in")
+	mappingPath := flags.String("map", "", `JSON file translating IDs in the source workspace to IDs in the target: {"sources": {"1": 2}, "destinations": {...}, "datasets": {...}}`)
+	dryRun := flags.Bool("dry-run", false, "report what would be created without creating anything")
+	reportPath := flags.String("report", "", "also write the report as JSON to this file")
+	region := flags.String("region", provider.DefaultRegion, "Census region ("+provider.RegionList()+"), unless CENSUS_BASE_URL is set")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-census %s -from ID -to ID -map FILE [flags]\n\n", Command)
+		fmt.Fprintf(stderr, "Copies the datasets and syncs of one workspace into another.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *from == 0 || *to == 0 || *mappingPath == "" || flags.NArg() > 0 {
+		flags.Usage()
+		return 2
+	}
+
+	report, err := run(ctx, Options{SourceWorkspaceID: *from, TargetWorkspaceID: *to, DryRun: *dryRun}, *mappingPath, *region)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	WriteReport(stdout, report)
+	if *reportPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*reportPath, append(data, '\n'), 0o644)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: failed to write report: %v\n", err)
+			return 1
+		}
+	}
+	if report.Count(StatusFailed) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func run(ctx context.Context, opts Options, mappingPath, region string) (*Report, error) {
+	data, err := os.ReadFile(mappingPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping: %w", err)
+	}
+	if err := json.Unmarshal(data, &opts.Mapping); err != nil {
+		return nil, fmt.Errorf("failed to decode mapping %s: %w", mappingPath, err)
+	}
+
+	p := provider.Provider()
+	diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"region": region}))
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to configure provider: %s", diags[0].Summary)
+	}
+
+	return Clone(ctx, p, opts)
+}
+
+// WriteReport writes a report as a table followed by a summary line
+func WriteReport(w io.Writer, report *Report) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tTYPE\tSOURCE ID\tTARGET ID\tNAME\tREASON")
+	for _, result := range report.Results {
+		targetID := "-"
+		if result.TargetID != 0 {
+			targetID = fmt.Sprint(result.TargetID)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n", result.Status, result.ResourceType, result.SourceID, targetID, result.Name, result.Reason)
+	}
+	tw.Flush()
+
+	created := StatusCreated
+	if report.DryRun {
+		created = StatusWouldCreate
+	}
+	fmt.Fprintf(w, "\n%d %s, %d skipped, %d failed\n", report.Count(created), created, report.Count(StatusSkipped), report.Count(StatusFailed))
+}
@@ -0,0 +1,93 @@
+package clone
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// remapDataset points the configuration of a dataset at the target workspace. It reports whether the
+// dataset uses an object a dry run would create.
+func (c *cloner) remapDataset(config map[string]interface{}) (bool, error) {
+	config["workspace_id"] = strconv.Itoa(c.opts.TargetWorkspaceID)
+
+	sourceID, _ := config["source_id"].(int)
+	mapped, ok := c.opts.Mapping.Sources[sourceID]
+	if !ok {
+		return false, fmt.Errorf("source %d is not in the mapping", sourceID)
+	}
+	config["source_id"] = mapped
+	return false, nil
+}
+
+// remapSync points the configuration of a sync at the target workspace, translating its source and
+// destination connections, its dataset and the sync its sync_sequence trigger waits for. It reports
+// whether the sync uses an object a dry run would create.
+func (c *cloner) remapSync(config map[string]interface{}) (bool, error) {
+	config["workspace_id"] = strconv.Itoa(c.opts.TargetWorkspaceID)
+	usesPending := false
+
+	if attrs := firstBlock(config, "source_attributes"); attrs != nil {
+		if _, ok := attrs["cohort_id"]; ok {
+			return false, fmt.Errorf("cohort sources are not cloned")
+		}
+		connectionID, _ := attrs["connection_id"].(int)
+		mapped, ok := c.opts.Mapping.Sources[connectionID]
+		if !ok {
+			return false, fmt.Errorf("source %d is not in the mapping", connectionID)
+		}
+		attrs["connection_id"] = mapped
+
+		if object := firstBlock(attrs, "object"); object != nil {
+			switch objectType := object["type"]; objectType {
+			case "table":
+			case "dataset":
+				datasetID, err := strconv.Atoi(fmt.Sprint(object["id"]))
+				if err != nil {
+					return false, fmt.Errorf("invalid dataset ID %v", object["id"])
+				}
+				mapped, ok := c.datasets[datasetID]
+				if !ok {
+					return false, fmt.Errorf("dataset %d was not cloned", datasetID)
+				}
+				usesPending = usesPending || mapped == pending
+				object["id"] = strconv.Itoa(mapped)
+			default:
+				return false, fmt.Errorf("%v sources are not cloned", objectType)
+			}
+		}
+	}
+
+	if attrs := firstBlock(config, "destination_attributes"); attrs != nil {
+		connectionID, _ := attrs["connection_id"].(int)
+		mapped, ok := c.opts.Mapping.Destinations[connectionID]
+		if !ok {
+			return false, fmt.Errorf("destination %d is not in the mapping", connectionID)
+		}
+		attrs["connection_id"] = mapped
+	}
+
+	if sequence := firstBlock(firstBlock(firstBlock(config, "run_mode"), "triggers"), "sync_sequence"); sequence != nil {
+		syncID, _ := sequence["sync_id"].(int)
+		mapped, ok := c.syncs[syncID]
+		if !ok {
+			return false, fmt.Errorf("it is triggered by sync %d, which was not cloned", syncID)
+		}
+		usesPending = usesPending || mapped == pending
+		sequence["sync_id"] = mapped
+	}
+
+	return usesPending, nil
+}
+
+// firstBlock returns the single nested block key of a configuration, or nil
+func firstBlock(config map[string]interface{}, key string) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+	items, _ := config[key].([]interface{})
+	if len(items) == 0 {
+		return nil
+	}
+	block, _ := items[0].(map[string]interface{})
+	return block
+}
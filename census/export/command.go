@@ -0,0 +1,99 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+// Command is the name of the provider binary's export subcommand
+const Command = "export"
+
+// Main runs the export subcommand with args and returns its exit code. The provider is configured
+// from the same environment variables as in Terraform, such as CENSUS_PERSONAL_ACCESS_TOKEN.
+func Main(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var workspaceIDs []int
+	flags.Func("workspace", "ID of a workspace to export; repeat to export several (default every workspace)", func(value string) error {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid workspace ID: %s", value)
+		}
+		workspaceIDs = append(workspaceIDs, id)
+		return nil
+	})
+	output := flags.String("output", ".", "directory the .tf files are written to")
+	region := flags.String("region", provider.DefaultRegion, "Census region ("+provider.RegionList()+"), unless CENSUS_BASE_URL is set")
+	force := flags.Bool("force", false, "overwrite generated files that already exist")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-census %s [flags]\n\n", Command)
+		fmt.Fprintf(stderr, "Writes Terraform configuration and import blocks for existing Census objects.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %v\n", flags.Args())
+		flags.Usage()
+		return 2
+	}
+
+	if err := run(ctx, workspaceIDs, *output, *region, *force, stdout); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func run(ctx context.Context, workspaceIDs []int, output, region string, force bool, stdout io.Writer) error {
+	if !force {
+		for _, name := range FileNames() {
+			if _, err := os.Stat(filepath.Join(output, name)); err == nil {
+				return fmt.Errorf("%s already exists; use -force to overwrite it", filepath.Join(output, name))
+			}
+		}
+	}
+
+	p := provider.Provider()
+	diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"region": region}))
+	if diags.HasError() {
+		return fmt.Errorf("failed to configure provider: %s", diags[0].Summary)
+	}
+
+	generated, err := Export(ctx, p, Options{WorkspaceIDs: workspaceIDs})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(output, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	names := make([]string, 0, len(generated))
+	for name := range generated {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(output, name)
+		if err := os.WriteFile(path, generated[name], 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Fprintf(stdout, "Wrote %s\n", path)
+	}
+	return nil
+}
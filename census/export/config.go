@@ -0,0 +1,139 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// skipped are top-level attributes that are never read back from the API
+var skipped = map[string]bool{
+	"connection_secrets":  true,
+	"credentials_version": true,
+}
+
+// ReadConfig reads a Census object with its resource in the configured provider p and returns the
+// configuration that matches the state an import produces: the configurable attributes that are not
+// at their default, with sets as lists in a stable order and nested blocks as lists of maps. It
+// returns nil when the object does not exist. workspaceID is ignored for workspaces.
+func ReadConfig(ctx context.Context, p *schema.Provider, resourceType string, workspaceID, id int) (map[string]interface{}, error) {
+	r, ok := p.ResourcesMap[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("unknown resource type %s", resourceType)
+	}
+	state := &terraform.InstanceState{ID: strconv.Itoa(id), Attributes: map[string]string{}}
+	if resourceType != workspaceType {
+		state.Attributes["workspace_id"] = strconv.Itoa(workspaceID)
+	}
+
+	d := r.Data(state)
+	if diags := r.ReadContext(ctx, d, p.Meta()); diags.HasError() {
+		for _, diagnostic := range diags {
+			if diagnostic.Detail != "" {
+				return nil, fmt.Errorf("failed to read %s %d: %s: %s", resourceType, id, diagnostic.Summary, diagnostic.Detail)
+			}
+		}
+		return nil, fmt.Errorf("failed to read %s %d: %s", resourceType, id, diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, nil
+	}
+
+	values := map[string]interface{}{}
+	for key := range r.Schema {
+		values[key] = d.Get(key)
+	}
+	return configValues(r.Schema, values, true), nil
+}
+
+// configValues returns the configurable values of a resource or nested block
+func configValues(schemaMap map[string]*schema.Schema, values map[string]interface{}, topLevel bool) map[string]interface{} {
+	config := map[string]interface{}{}
+	for key, s := range schemaMap {
+		value := values[key]
+		switch {
+		case !s.Required && !s.Optional, s.Deprecated != "", topLevel && (skipped[key] || key == "id"):
+			continue
+		case isBlock(s):
+			elem := s.Elem.(*schema.Resource)
+			var items []interface{}
+			for _, item := range blockItems(value) {
+				items = append(items, configValues(elem.Schema, item, false))
+			}
+			if len(items) > 0 {
+				config[key] = items
+			}
+		case !s.Required && isDefault(s, value):
+			continue
+		default:
+			if set, ok := value.(*schema.Set); ok {
+				value = sortedList(set)
+			}
+			config[key] = value
+		}
+	}
+	return config
+}
+
+// isDefault reports whether an optional attribute can be left out because value is its default, or
+// its zero value when it has none
+func isDefault(s *schema.Schema, value interface{}) bool {
+	if s.Default != nil {
+		return value == s.Default
+	}
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// blockItems returns the nested blocks of a list or set, with sets in a stable order
+func blockItems(value interface{}) []map[string]interface{} {
+	var list []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		list = v
+	case *schema.Set:
+		list = sortedList(v)
+	}
+
+	items := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok {
+			items = append(items, m)
+		}
+	}
+	return items
+}
+
+func sortKey(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+// sortedList returns the elements of a set in a stable order
+func sortedList(set *schema.Set) []interface{} {
+	list := set.List()
+	sort.SliceStable(list, func(i, j int) bool { return sortKey(list[i]) < sortKey(list[j]) })
+	return list
+}
@@ -0,0 +1,458 @@
+// Package export generates Terraform configuration for Census workspaces that were created outside
+// Terraform: a resource block and a matching import block for every workspace, source, destination,
+// dataset and sync. Objects are read with the provider's own resources, so the generated configuration
+// matches the state an import produces.
+package export
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+// Resource types in the order they are exported
+const (
+	workspaceType   = "census_workspace"
+	sourceType      = "census_source"
+	destinationType = "census_destination"
+	datasetType     = "census_dataset"
+	syncType        = "census_sync"
+)
+
+// files are the generated file names of each resource type
+var files = map[string]string{
+	workspaceType:   "workspaces.tf",
+	sourceType:      "sources.tf",
+	destinationType: "destinations.tf",
+	datasetType:     "datasets.tf",
+	syncType:        "syncs.tf",
+}
+
+const (
+	importsFile   = "imports.tf"
+	variablesFile = "variables.tf"
+)
+
+// FileNames returns the names of the files Export generates
+func FileNames() []string {
+	names := []string{}
+	for _, resourceType := range []string{workspaceType, sourceType, destinationType, datasetType, syncType} {
+		names = append(names, files[resourceType])
+	}
+	return append(names, importsFile, variablesFile)
+}
+
+// Options configures an export
+type Options struct {
+	// WorkspaceIDs limits the export to these workspaces. Every workspace is exported when it is empty.
+	WorkspaceIDs []int
+}
+
+// object is an exported Census object and the address of its resource
+type object struct {
+	resourceType string
+	name         string
+	id           int
+	workspaceID  int
+
+	// secret connection fields of a source or destination, and whether the catalog requires them
+	secretFields map[string]bool
+}
+
+func (o *object) address() string {
+	return o.resourceType + "." + o.name
+}
+
+func (o *object) importID() string {
+	if o.resourceType == workspaceType {
+		return strconv.Itoa(o.id)
+	}
+	return fmt.Sprintf("%d:%d", o.workspaceID, o.id)
+}
+
+// exporter collects the objects of every exported workspace before rendering them, so that
+// references can point at objects that are rendered later
+type exporter struct {
+	p         *schema.Provider
+	apiClient *client.Client
+
+	objects []*object
+	// resource type -> Census ID -> object
+	byID map[string]map[int]*object
+	// resource type -> names in use
+	names map[string]map[string]bool
+}
+
+// Export reads the workspaces in opts with the configured provider p and returns the generated files
+// by name
+func Export(ctx context.Context, p *schema.Provider, opts Options) (map[string][]byte, error) {
+	apiClient, ok := p.Meta().(*client.Client)
+	if !ok {
+		return nil, fmt.Errorf("provider is not configured")
+	}
+	e := &exporter{
+		p:         p,
+		apiClient: apiClient,
+		byID:      map[string]map[int]*object{},
+		names:     map[string]map[string]bool{},
+	}
+
+	workspaces, err := e.workspaces(ctx, opts.WorkspaceIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, workspace := range workspaces {
+		if err := e.collectWorkspace(ctx, workspace); err != nil {
+			return nil, fmt.Errorf("failed to export workspace %d: %w", workspace.ID, err)
+		}
+	}
+
+	return e.render(ctx)
+}
+
+func (e *exporter) workspaces(ctx context.Context, ids []int) ([]client.Workspace, error) {
+	if len(ids) == 0 {
+		workspaces, err := e.apiClient.ListAllWorkspaces(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workspaces: %w", err)
+		}
+		sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].ID < workspaces[j].ID })
+		return workspaces, nil
+	}
+
+	workspaces := make([]client.Workspace, 0, len(ids))
+	for _, id := range ids {
+		workspace, err := e.apiClient.GetWorkspace(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workspace %d: %w", id, err)
+		}
+		workspaces = append(workspaces, *workspace)
+	}
+	return workspaces, nil
+}
+
+// collectWorkspace names the workspace and every object in it
+func (e *exporter) collectWorkspace(ctx context.Context, workspace client.Workspace) error {
+	e.add(workspaceType, workspace.ID, workspace.ID, workspace.Name)
+
+	token, err := e.apiClient.GetCachedWorkspaceAPIKey(ctx, workspace.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace API key: %w", err)
+	}
+
+	sources, err := e.apiClient.ListAllSourcesWithToken(ctx, nil, token)
+	if err != nil {
+		return fmt.Errorf("failed to list sources: %w", err)
+	}
+	if len(sources) > 0 {
+		sourceTypes, err := e.apiClient.GetSourceTypes(ctx, token)
+		if err != nil {
+			return fmt.Errorf("failed to read source types: %w", err)
+		}
+		catalog := map[string][]provider.CatalogField{}
+		for _, st := range sourceTypes {
+			catalog[st.ServiceName] = provider.SourceTypeCatalogFields(st)
+		}
+		sort.Slice(sources, func(i, j int) bool { return sources[i].ID < sources[j].ID })
+		for _, source := range sources {
+			o := e.add(sourceType, workspace.ID, source.ID, source.Name)
+			o.secretFields = secretFields(catalog[source.Type])
+		}
+	}
+
+	destinations, err := e.apiClient.ListAllDestinationsWithToken(ctx, nil, token)
+	if err != nil {
+		return fmt.Errorf("failed to list destinations: %w", err)
+	}
+	if len(destinations) > 0 {
+		connectors, err := e.apiClient.GetConnectors(ctx, token)
+		if err != nil {
+			return fmt.Errorf("failed to read destination connectors: %w", err)
+		}
+		catalog := map[string][]provider.CatalogField{}
+		for _, connector := range connectors {
+			catalog[connector.ServiceName] = provider.ConnectorCatalogFields(connector)
+		}
+		sort.Slice(destinations, func(i, j int) bool { return destinations[i].ID < destinations[j].ID })
+		for _, destination := range destinations {
+			o := e.add(destinationType, workspace.ID, destination.ID, destination.Name)
+			o.secretFields = secretFields(catalog[destination.Type])
+		}
+	}
+
+	datasets, err := e.apiClient.ListAllDatasetsWithToken(ctx, nil, token)
+	if err != nil {
+		return fmt.Errorf("failed to list datasets: %w", err)
+	}
+	sort.Slice(datasets, func(i, j int) bool { return datasets[i].ID < datasets[j].ID })
+	for _, dataset := range datasets {
+		e.add(datasetType, workspace.ID, dataset.ID, dataset.Name)
+	}
+
+	syncs, err := e.apiClient.ListAllSyncsWithToken(ctx, nil, token)
+	if err != nil {
+		return fmt.Errorf("failed to list syncs: %w", err)
+	}
+	sort.Slice(syncs, func(i, j int) bool { return syncs[i].ID < syncs[j].ID })
+	for _, sync := range syncs {
+		e.add(syncType, workspace.ID, sync.ID, sync.Label)
+	}
+
+	return nil
+}
+
+// secretFields returns the secret fields of a connector catalog and whether each is required
+func secretFields(fields []provider.CatalogField) map[string]bool {
+	secret := map[string]bool{}
+	for _, f := range fields {
+		if f.IsPassword {
+			secret[f.ID] = f.Required()
+		}
+	}
+	return secret
+}
+
+// add registers an object under a resource name derived from label that is unique for its type
+func (e *exporter) add(resourceType string, workspaceID, id int, label string) *object {
+	if e.names[resourceType] == nil {
+		e.names[resourceType] = map[string]bool{}
+		e.byID[resourceType] = map[int]*object{}
+	}
+
+	base := resourceName(label, strings.TrimPrefix(resourceType, "census_"))
+	name := base
+	for i := 2; e.names[resourceType][name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.names[resourceType][name] = true
+
+	o := &object{resourceType: resourceType, name: name, id: id, workspaceID: workspaceID}
+	e.objects = append(e.objects, o)
+	e.byID[resourceType][id] = o
+	return o
+}
+
+var nonIdentifier = regexp.MustCompile(`[^a-z0-9]+`)
+
+// resourceName converts a Census name or label to a Terraform resource name, prefixed with kind when
+// it would not start with a letter
+func resourceName(label, kind string) string {
+	name := strings.Trim(nonIdentifier.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = strings.Trim(kind+"_"+name, "_")
+	}
+	return name
+}
+
+// render reads every object with its provider resource and writes the generated files
+func (e *exporter) render(ctx context.Context) (map[string][]byte, error) {
+	resourceFiles := map[string]*hclwrite.File{}
+	imports := newFile("Import blocks for the exported resources. Remove them once the resources are imported.")
+	variables := newFile("Secret connection fields, which the Census API does not return.")
+
+	for _, o := range e.objects {
+		state, err := e.read(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		if state == nil {
+			// Deleted since it was listed
+			continue
+		}
+
+		f, ok := resourceFiles[o.resourceType]
+		if !ok {
+			f = newFile(fmt.Sprintf("%s resources exported from Census.", o.resourceType))
+			resourceFiles[o.resourceType] = f
+		}
+		body := f.Body()
+		body.AppendNewline()
+		block := body.AppendNewBlock("resource", []string{o.resourceType, o.name})
+		e.writeBody(block.Body(), e.p.ResourcesMap[o.resourceType].Schema, state, "")
+		if o.secretFields != nil {
+			e.writeSecrets(block.Body(), variables.Body(), o)
+		}
+
+		importBody := imports.Body()
+		importBody.AppendNewline()
+		importBlock := importBody.AppendNewBlock("import", nil).Body()
+		importBlock.SetAttributeTraversal("to", traversal(o.resourceType, o.name))
+		importBlock.SetAttributeValue("id", ctyString(o.importID()))
+	}
+
+	generated := map[string][]byte{}
+	for resourceType, f := range resourceFiles {
+		generated[files[resourceType]] = f.Bytes()
+	}
+	generated[importsFile] = imports.Bytes()
+	generated[variablesFile] = variables.Bytes()
+	return generated, nil
+}
+
+// read returns the configuration of an object, or nil when it no longer exists
+func (e *exporter) read(ctx context.Context, o *object) (map[string]interface{}, error) {
+	config, err := ReadConfig(ctx, e.p, o.resourceType, o.workspaceID, o.id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", o.address(), err)
+	}
+	return config, nil
+}
+
+// writeSecrets sets connection_secrets to variables for the secret fields the catalog requires, and
+// lists the optional ones in a comment
+func (e *exporter) writeSecrets(body, variables *hclwrite.Body, o *object) {
+	var required, optional []string
+	for field, isRequired := range o.secretFields {
+		if isRequired {
+			required = append(required, field)
+		} else {
+			optional = append(optional, field)
+		}
+	}
+	sort.Strings(required)
+	sort.Strings(optional)
+
+	if len(optional) > 0 {
+		appendComment(body, fmt.Sprintf("Optional secret fields, which can be added to connection_secrets: %s", strings.Join(optional, ", ")))
+	}
+	if len(required) == 0 {
+		return
+	}
+
+	attrs := make([]hclwrite.ObjectAttrTokens, 0, len(required))
+	for _, field := range required {
+		variable := strings.TrimPrefix(o.resourceType, "census_") + "_" + o.name + "_" + resourceName(field, "field")
+		attrs = append(attrs, hclwrite.ObjectAttrTokens{
+			Name:  objectKeyTokens(field),
+			Value: hclwrite.TokensForTraversal(traversal("var", variable)),
+		})
+
+		variables.AppendNewline()
+		block := variables.AppendNewBlock("variable", []string{variable}).Body()
+		block.SetAttributeValue("description", ctyString(fmt.Sprintf("%s of %s", field, o.address())))
+		block.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		block.SetAttributeValue("sensitive", ctyBool(true))
+	}
+	body.SetAttributeRaw("connection_secrets", hclwrite.TokensForObject(attrs))
+}
+
+// references are the attributes, by path, whose IDs are written as references to another exported
+// resource. object.id is a dataset ID only for dataset objects.
+var references = map[string]string{
+	"workspace_id":                            workspaceType,
+	"source_id":                               sourceType,
+	"source_attributes.connection_id":         sourceType,
+	"source_attributes.object.id":             datasetType,
+	"source_attributes.object.dataset_id":     datasetType,
+	"destination_attributes.connection_id":    destinationType,
+	"run_mode.triggers.sync_sequence.sync_id": syncType,
+}
+
+// reference returns a reference to the exported resource an attribute value points at
+func (e *exporter) reference(path string, values map[string]interface{}, value interface{}) (hcl.Traversal, bool) {
+	resourceType, ok := references[path]
+	if !ok {
+		return nil, false
+	}
+	if path == "source_attributes.object.id" && values["type"] != "dataset" {
+		return nil, false
+	}
+	id, err := strconv.Atoi(fmt.Sprint(value))
+	if err != nil {
+		return nil, false
+	}
+	o, ok := e.byID[resourceType][id]
+	if !ok {
+		return nil, false
+	}
+	return traversal(o.resourceType, o.name, "id"), true
+}
+
+// leading are the attributes written before the others, in this order
+var leading = []string{"workspace_id", "name", "label", "type"}
+
+// sortAttributes sorts attribute names with the leading attributes first
+func sortAttributes(names []string) {
+	rank := func(name string) int {
+		for i, l := range leading {
+			if name == l {
+				return i
+			}
+		}
+		return len(leading)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if ri, rj := rank(names[i]), rank(names[j]); ri != rj {
+			return ri < rj
+		}
+		return names[i] < names[j]
+	})
+}
+
+// writeBody writes configuration values to body: required attributes first, then optional
+// attributes, then nested blocks
+func (e *exporter) writeBody(body *hclwrite.Body, schemaMap map[string]*schema.Schema, values map[string]interface{}, path string) {
+	var attributes, optional, blocks []string
+	for key := range values {
+		s := schemaMap[key]
+		switch {
+		case isBlock(s):
+			blocks = append(blocks, key)
+		case s.Required:
+			attributes = append(attributes, key)
+		default:
+			optional = append(optional, key)
+		}
+	}
+	sortAttributes(attributes)
+	sortAttributes(optional)
+	sort.Strings(blocks)
+
+	for _, key := range append(attributes, optional...) {
+		s, value := schemaMap[key], values[key]
+		if ref, ok := e.reference(path+key, values, value); ok {
+			body.SetAttributeTraversal(key, ref)
+			continue
+		}
+		body.SetAttributeValue(key, ctyValue(s, value))
+	}
+
+	for _, key := range blocks {
+		elem := schemaMap[key].Elem.(*schema.Resource)
+		for _, item := range blockItems(values[key]) {
+			block := body.AppendNewBlock(key, nil)
+			e.writeBody(block.Body(), elem.Schema, item, path+key+".")
+		}
+	}
+}
+
+func newFile(comment string) *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+	appendComment(f.Body(), "Generated by terraform-provider-census export. "+comment)
+	return f
+}
+
+func appendComment(body *hclwrite.Body, text string) {
+	body.AppendUnstructuredTokens(hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# " + text + "\n")},
+	})
+}
+
+func traversal(root string, attrs ...string) hcl.Traversal {
+	t := hcl.Traversal{hcl.TraverseRoot{Name: root}}
+	for _, attr := range attrs {
+		t = append(t, hcl.TraverseAttr{Name: attr})
+	}
+	return t
+}
@@ -0,0 +1,103 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// isBlock reports whether an attribute is written as nested blocks
+func isBlock(s *schema.Schema) bool {
+	_, ok := s.Elem.(*schema.Resource)
+	return ok && (s.Type == schema.TypeList || s.Type == schema.TypeSet)
+}
+
+// ctyValue converts a value read from ResourceData to the value written for its attribute
+func ctyValue(s *schema.Schema, value interface{}) cty.Value {
+	switch s.Type {
+	case schema.TypeList, schema.TypeSet:
+		var list []interface{}
+		if set, ok := value.(*schema.Set); ok {
+			list = sortedList(set)
+		} else {
+			list, _ = value.([]interface{})
+		}
+		elem := elemSchema(s)
+		if len(list) == 0 {
+			return cty.ListValEmpty(ctyType(elem.Type))
+		}
+		values := make([]cty.Value, 0, len(list))
+		for _, item := range list {
+			values = append(values, ctyValue(elem, item))
+		}
+		return cty.ListVal(values)
+	case schema.TypeMap:
+		m, _ := value.(map[string]interface{})
+		elem := elemSchema(s)
+		if len(m) == 0 {
+			return cty.MapValEmpty(ctyType(elem.Type))
+		}
+		values := make(map[string]cty.Value, len(m))
+		for key, item := range m {
+			values[key] = ctyValue(elem, item)
+		}
+		return cty.MapVal(values)
+	case schema.TypeInt:
+		if v, ok := value.(int); ok {
+			return cty.NumberIntVal(int64(v))
+		}
+	case schema.TypeFloat:
+		if v, ok := value.(float64); ok {
+			return cty.NumberFloatVal(v)
+		}
+	case schema.TypeBool:
+		if v, ok := value.(bool); ok {
+			return ctyBool(v)
+		}
+	}
+	if value == nil {
+		return cty.NullVal(ctyType(s.Type))
+	}
+	return ctyString(fmt.Sprint(value))
+}
+
+// elemSchema returns the schema of the elements of a primitive list, set or map. Maps without an
+// element type hold strings.
+func elemSchema(s *schema.Schema) *schema.Schema {
+	switch elem := s.Elem.(type) {
+	case *schema.Schema:
+		return elem
+	case schema.ValueType:
+		return &schema.Schema{Type: elem}
+	}
+	return &schema.Schema{Type: schema.TypeString}
+}
+
+func ctyType(t schema.ValueType) cty.Type {
+	switch t {
+	case schema.TypeInt, schema.TypeFloat:
+		return cty.Number
+	case schema.TypeBool:
+		return cty.Bool
+	}
+	return cty.String
+}
+
+func ctyString(s string) cty.Value {
+	return cty.StringVal(s)
+}
+
+func ctyBool(b bool) cty.Value {
+	return cty.BoolVal(b)
+}
+
+// objectKeyTokens returns an object key, quoted unless it is a valid identifier
+func objectKeyTokens(key string) hclwrite.Tokens {
+	if hclsyntax.ValidIdentifier(key) {
+		return hclwrite.TokensForIdentifier(key)
+	}
+	return hclwrite.TokensForValue(cty.StringVal(key))
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// secretHashPrefix marks a connection_config value in state that is a salted hash of a secret field,
+// stored as secretHashPrefix + salt + ":" + HMAC-SHA256 of the value keyed by the salt
+const secretHashPrefix = "hmac-sha256:"
+
+// hashConnectionSecret returns the salted hash of a secret connection field stored in state
+func hashConnectionSecret(value string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return secretHashPrefix + hex.EncodeToString(salt) + ":" + connectionSecretDigest(salt, value), nil
+}
+
+func connectionSecretDigest(salt []byte, value string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// isHashedConnectionSecret reports whether a connection_config value in state is a secret hash
+func isHashedConnectionSecret(stored string) bool {
+	return strings.HasPrefix(stored, secretHashPrefix)
+}
+
+// connectionSecretMatches reports whether value hashes to a secret hash stored in state
+func connectionSecretMatches(stored, value string) bool {
+	if !isHashedConnectionSecret(stored) {
+		return false
+	}
+	salt, digest, ok := strings.Cut(strings.TrimPrefix(stored, secretHashPrefix), ":")
+	if !ok {
+		return false
+	}
+	saltBytes, err := hex.DecodeString(salt)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(digest), []byte(connectionSecretDigest(saltBytes, value)))
+}
+
+// suppressHashedSecretDiff is the DiffSuppressFunc of connection_config. Secret fields are stored as
+// salted hashes, so a configured value is unchanged when it hashes to the stored one.
+func suppressHashedSecretDiff(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return connectionSecretMatches(oldValue, newValue)
+}
+
+// connectionConfigChanged reports whether connection_config changes, comparing secret fields with
+// their stored hashes. ResourceDiff.HasChange does not apply DiffSuppressFunc.
+func connectionConfigChanged(d *schema.ResourceDiff) bool {
+	if !d.NewValueKnown("connection_config") {
+		return true
+	}
+
+	o, n := d.GetChange("connection_config")
+	oldConfig, newConfig := o.(map[string]interface{}), n.(map[string]interface{})
+	if len(oldConfig) != len(newConfig) {
+		return true
+	}
+	for key, newValue := range newConfig {
+		oldValue, ok := oldConfig[key].(string)
+		if !ok || (oldValue != newValue && !suppressHashedSecretDiff(key, oldValue, newValue.(string), nil)) {
+			return true
+		}
+	}
+	return false
+}
+
+// configuredConnectionConfig returns connection_config as configured. Secret fields are only hashes
+// in state, so their values are read from the raw configuration.
+func configuredConnectionConfig(d *schema.ResourceData) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	for key, value := range d.Get("connection_config").(map[string]interface{}) {
+		config[key] = value
+	}
+	for key, value := range rawConfigStringMap(d.GetRawConfig(), "connection_config") {
+		config[key] = value
+	}
+
+	for key, value := range config {
+		if s, ok := value.(string); ok && isHashedConnectionSecret(s) {
+			return nil, fmt.Errorf("connection_config[%q]: only a hash of this secret is stored, and its configured value is not available", key)
+		}
+	}
+	return config, nil
+}
+
+// storedConnectionConfig returns connection_config as it is stored in state, with the fields the
+// connector catalog marks as secret replaced by salted hashes
+func storedConnectionConfig(config map[string]interface{}, secretFields map[string]bool) (map[string]interface{}, error) {
+	stored := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		s, ok := value.(string)
+		if !ok || !secretFields[key] {
+			stored[key] = value
+			continue
+		}
+		hash, err := hashConnectionSecret(s)
+		if err != nil {
+			return nil, fmt.Errorf("connection_config[%q]: %w", key, err)
+		}
+		stored[key] = hash
+	}
+	return stored, nil
+}
+
+// setStoredConnectionConfig stores connection_config with its secret fields hashed. When the catalog
+// cannot say which fields are secret, every field is hashed rather than risk storing a secret.
+func setStoredConnectionConfig(ctx context.Context, d *schema.ResourceData, config map[string]interface{}, secretFields map[string]bool, catalogErr error) error {
+	if catalogErr != nil {
+		tflog.Warn(ctx, "Failed to read which connection fields are secret, storing every connection_config field as a hash", map[string]interface{}{
+			"error": catalogErr.Error(),
+		})
+		secretFields = make(map[string]bool, len(config))
+		for key := range config {
+			secretFields[key] = true
+		}
+	}
+
+	stored, err := storedConnectionConfig(config, secretFields)
+	if err != nil {
+		return err
+	}
+	return d.Set("connection_config", stored)
+}
+
+// readConnectionConfig refreshes the public connection_config fields from the connection returned by
+// the API, so changes made outside Terraform are planned. Secret hashes and fields the API does not
+// return are kept as stored. When nothing is stored, as after an import, every field the API returns
+// is stored; the API does not return secrets.
+func readConnectionConfig(d *schema.ResourceData, connection map[string]interface{}) error {
+	if len(connection) == 0 {
+		return nil
+	}
+
+	stored := d.Get("connection_config").(map[string]interface{})
+	if len(stored) == 0 {
+		config := make(map[string]interface{}, len(connection))
+		for key, remote := range connection {
+			if remote != nil {
+				config[key] = flattenConnectionValue(remote)
+			}
+		}
+		return d.Set("connection_config", config)
+	}
+
+	config := make(map[string]interface{}, len(stored))
+	for key, value := range stored {
+		config[key] = value
+		if s, _ := value.(string); isHashedConnectionSecret(s) {
+			continue
+		}
+		if remote, ok := connection[key]; ok && remote != nil {
+			config[key] = flattenConnectionValue(remote)
+		}
+	}
+	return d.Set("connection_config", config)
+}
+
+// flattenConnectionValue converts a connection value from the API to its connection_config string,
+// the reverse of expandConnectionConfig
+func flattenConnectionValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
+
+// sourceSecretFields returns the fields of a source type that the catalog marks as secret
+func sourceSecretFields(ctx context.Context, apiClient *client.Client, sourceType, workspaceToken string) (map[string]bool, error) {
+	sourceTypes, err := apiClient.GetSourceTypes(ctx, workspaceToken)
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range sourceTypes {
+		if st.ServiceName == sourceType {
+			return catalogSecretFields(SourceTypeCatalogFields(st)), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown source type: %s", sourceType)
+}
+
+// destinationSecretFields returns the fields of a destination connector that the catalog marks as secret
+func destinationSecretFields(ctx context.Context, apiClient *client.Client, destinationType, workspaceToken string) (map[string]bool, error) {
+	connectors, err := apiClient.GetConnectors(ctx, workspaceToken)
+	if err != nil {
+		return nil, err
+	}
+	for _, connector := range connectors {
+		if connector.ServiceName == destinationType {
+			return catalogSecretFields(ConnectorCatalogFields(connector)), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown destination type: %s", destinationType)
+}
+
+func catalogSecretFields(fields []CatalogField) map[string]bool {
+	secret := make(map[string]bool)
+	for _, f := range fields {
+		if f.IsPassword {
+			secret[f.ID] = true
+		}
+	}
+	return secret
+}
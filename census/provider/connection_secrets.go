@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// connectionSecretsSchema is the write-only connection_secrets attribute of census_source and
+// census_destination. Its values are read from the configuration when a connection is created or
+// updated and are never stored in the planned or new state. SDKv2 has no write-only attributes, so
+// this relies on the suppressed diff keeping the planned map null; the values remain in the
+// configuration and in saved plan files.
+func connectionSecretsSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeMap,
+		Optional:  true,
+		Sensitive: true,
+		Elem:      &schema.Schema{Type: schema.TypeString},
+		// Changes are never planned, because nothing is stored to compare against. Change
+		// credentials_version to send new values.
+		DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+			return true
+		},
+		Description: fmt.Sprintf("Write-only connection credentials for the %s, such as passwords, private keys and OAuth secrets. "+
+			"They are merged into `connection_config` when the %s is created or updated and are never stored in state. "+
+			"They still appear in the configuration and in saved plan files. "+
+			"Change `credentials_version` to send new values.", kind, kind),
+	}
+}
+
+// credentialsVersionSchema is the credentials_version attribute that triggers sending connection_secrets
+func credentialsVersionSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: fmt.Sprintf("An arbitrary value, such as a number or a hash of the secrets, that updates the %s with the current `connection_secrets` when it changes.", kind),
+	}
+}
+
+// connectionCustomizeDiff is the CustomizeDiff of census_source and census_destination
+func connectionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The API does not return credentials, so updated_at is the only attribute that changes
+	if connectionConfigChanged(d) || d.HasChange("credentials_version") {
+		d.SetNewComputed("updated_at")
+	}
+	return validateConnectionSecrets(d.GetRawConfig())
+}
+
+// validateConnectionSecrets rejects connection_secrets keys that are also set in connection_config
+func validateConnectionSecrets(config cty.Value) error {
+	secrets := rawConfigStringMap(config, "connection_secrets")
+	public := rawConfigStringMap(config, "connection_config")
+
+	var errs []error
+	for _, key := range sortedKeys(secrets) {
+		if _, ok := public[key]; ok {
+			errs = append(errs, fmt.Errorf("connection_secrets[%q]: also set in connection_config, set it in one of them", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// connectionCredentials returns the credentials to send for a connection, with the write-only
+// connection_secrets from the configuration merged over the configured connection_config
+func connectionCredentials(d *schema.ResourceData, config map[string]interface{}) map[string]interface{} {
+	credentials := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		credentials[key] = value
+	}
+	for key, value := range rawConfigStringMap(d.GetRawConfig(), "connection_secrets") {
+		credentials[key] = value
+	}
+	return expandConnectionConfig(credentials)
+}
+
+// rawConfigStringMap returns the known values of a map of strings in the raw configuration
+func rawConfigStringMap(config cty.Value, attribute string) map[string]string {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(attribute) {
+		return nil
+	}
+
+	value := config.GetAttr(attribute)
+	if value.IsNull() || !value.IsKnown() {
+		return nil
+	}
+
+	result := make(map[string]string)
+	for it := value.ElementIterator(); it.Next(); {
+		key, element := it.Element()
+		if element.IsNull() || !element.IsKnown() || !element.Type().Equals(cty.String) {
+			continue
+		}
+		result[key.AsString()] = element.AsString()
+	}
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// CatalogField is a connector configuration field from the source type or connector catalog,
+// normalized so both catalogs can be exposed and validated the same way
+type CatalogField struct {
+	ID                    string
+	Label                 string
+	Type                  string
+	Rules                 []string
+	Placeholder           interface{}
+	IsPassword            bool
+	PossibleValues        []string
+	Show                  interface{}
+	ConditionallyRequired interface{}
+}
+
+// Required reports whether the field must be set when the connection is created
+func (f CatalogField) Required() bool {
+	for _, rule := range f.Rules {
+		if rule == "required" || rule == "required:notForEditing" {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceTypeCatalogFields normalizes the configuration fields of a source type
+func SourceTypeCatalogFields(sourceType client.SourceType) []CatalogField {
+	fields := make([]CatalogField, 0, len(sourceType.ConfigurationFields.Fields))
+	for _, f := range sourceType.ConfigurationFields.Fields {
+		fields = append(fields, CatalogField{
+			ID:                    f.ID,
+			Label:                 f.Label,
+			Type:                  f.Type,
+			Rules:                 f.Rules,
+			Placeholder:           f.Placeholder,
+			IsPassword:            f.IsPasswordTypeField,
+			PossibleValues:        f.PossibleValues,
+			Show:                  f.Show,
+			ConditionallyRequired: f.ConditionallyRequired,
+		})
+	}
+	return fields
+}
+
+// ConnectorCatalogFields normalizes the configuration fields of a destination connector.
+// Connector rules are returned either as a single string or as a list.
+func ConnectorCatalogFields(connector client.Connector) []CatalogField {
+	fields := make([]CatalogField, 0, len(connector.ConfigurationFields.Fields))
+	for _, f := range connector.ConfigurationFields.Fields {
+		var rules []string
+		switch r := f.Rules.(type) {
+		case string:
+			rules = []string{r}
+		case []interface{}:
+			for _, rule := range r {
+				if ruleStr, ok := rule.(string); ok {
+					rules = append(rules, ruleStr)
+				}
+			}
+		}
+
+		fields = append(fields, CatalogField{
+			ID:                    f.ID,
+			Label:                 f.Label,
+			Type:                  f.Type,
+			Rules:                 rules,
+			Placeholder:           f.Placeholder,
+			IsPassword:            f.IsPasswordTypeField,
+			PossibleValues:        f.PossibleValues,
+			Show:                  f.Show,
+			ConditionallyRequired: f.ConditionallyRequired,
+		})
+	}
+	return fields
+}
+
+// catalogFieldSchema is the element schema of the configuration_fields attribute of the catalog data sources
+func catalogFieldSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the field in `connection_config`.",
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The human readable label of the field.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The input type of the field (e.g., string, boolean, select).",
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The validation rules the API applies to the field.",
+			},
+			"required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the field is required when creating the connection.",
+			},
+			"is_password": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the field holds a secret such as a password or private key.",
+			},
+			"placeholder": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Example value shown for the field, JSON encoded when it is not a string.",
+			},
+			"possible_values": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The allowed values for select fields.",
+			},
+			"show": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON encoded condition under which the field applies, or empty when it always applies.",
+			},
+			"conditionally_required": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON encoded condition under which the field becomes required, or empty.",
+			},
+		},
+	}
+}
+
+// flattenCatalogFields converts catalog fields to the configuration_fields attribute
+func flattenCatalogFields(fields []CatalogField) []interface{} {
+	result := make([]interface{}, 0, len(fields))
+	for _, f := range fields {
+		result = append(result, map[string]interface{}{
+			"id":                     f.ID,
+			"label":                  f.Label,
+			"type":                   f.Type,
+			"rules":                  f.Rules,
+			"required":               f.Required(),
+			"is_password":            f.IsPassword,
+			"placeholder":            catalogValueString(f.Placeholder),
+			"possible_values":        f.PossibleValues,
+			"show":                   catalogValueString(f.Show),
+			"conditionally_required": catalogValueString(f.ConditionallyRequired),
+		})
+	}
+	return result
+}
+
+// catalogValueString returns strings as-is and JSON encodes any other non-nil catalog value
+func catalogValueString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(encoded)
+}
@@ -17,21 +17,13 @@ func dataSourceDataset() *schema.Resource {
 		ReadContext: dataSourceDatasetRead,
 
 		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of the dataset.",
-			},
+			"id": lookupIDSchema("dataset", "name"),
 			"workspace_id": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The ID of the workspace this dataset belongs to.",
 			},
-			"name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The name of the dataset.",
-			},
+			"name": lookupNameSchema("The name of the dataset.", "name"),
 			"type": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -98,25 +90,34 @@ func dataSourceDataset() *schema.Resource {
 func dataSourceDatasetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*client.Client)
 
-	id, err := strconv.Atoi(d.Get("id").(string))
+	id, byID, err := lookupID(d, "dataset")
 	if err != nil {
-		return diag.Errorf("invalid dataset ID: %s", d.Get("id").(string))
+		return diag.FromErr(err)
 	}
 
 	workspaceId := d.Get("workspace_id").(string)
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
-	}
-
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.Errorf("failed to get workspace API key: %v", err)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
-	dataset, err := apiClient.GetDatasetWithToken(ctx, id, workspaceToken)
-	if err != nil {
-		return diag.FromErr(err)
+	var dataset *client.Dataset
+	if byID {
+		dataset, err = apiClient.GetDatasetWithToken(ctx, id, workspaceToken)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		datasets, err := apiClient.ListAllDatasetsWithToken(ctx, nil, workspaceToken)
+		if err != nil {
+			return diag.Errorf("failed to list datasets: %v", err)
+		}
+		dataset, err = FindByName(datasets, "dataset", "name", d.Get("name").(string),
+			func(s client.Dataset) string { return s.Name },
+			func(s client.Dataset) int { return s.ID })
+		if err != nil {
+			return diag.Errorf("failed to look up dataset in workspace %s: %v", workspaceId, err)
+		}
 	}
 
 	if dataset == nil {
@@ -125,43 +126,49 @@ func dataSourceDatasetRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	d.SetId(strconv.Itoa(dataset.ID))
 	d.Set("workspace_id", workspaceId)
-	d.Set("name", dataset.Name)
-	d.Set("type", dataset.Type)
-	d.Set("query", dataset.Query)
-	d.Set("source_id", dataset.SourceID)
-	d.Set("resource_identifier", dataset.ResourceIdentifier)
+	if err := setDataSourceAttributes(d, flattenDatasetForDataSource(dataset)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenDatasetForDataSource converts a dataset into the computed attributes shared by census_dataset and census_datasets
+func flattenDatasetForDataSource(dataset *client.Dataset) map[string]interface{} {
+	result := map[string]interface{}{
+		"name":                dataset.Name,
+		"type":                dataset.Type,
+		"query":               dataset.Query,
+		"source_id":           dataset.SourceID,
+		"resource_identifier": dataset.ResourceIdentifier,
+	}
 
 	// Set optional fields with nil checks
 	if dataset.Description != nil {
-		d.Set("description", *dataset.Description)
+		result["description"] = *dataset.Description
 	}
 
 	if dataset.CachedRecordCount != nil {
-		d.Set("cached_record_count", *dataset.CachedRecordCount)
+		result["cached_record_count"] = *dataset.CachedRecordCount
 	}
 
 	// Set time fields
 	if !dataset.CreatedAt.IsZero() {
-		d.Set("created_at", dataset.CreatedAt.Format("2006-01-02T15:04:05Z"))
+		result["created_at"] = dataset.CreatedAt.Format("2006-01-02T15:04:05Z")
 	}
 	if !dataset.UpdatedAt.IsZero() {
-		d.Set("updated_at", dataset.UpdatedAt.Format("2006-01-02T15:04:05Z"))
+		result["updated_at"] = dataset.UpdatedAt.Format("2006-01-02T15:04:05Z")
 	}
 
 	// Set columns - always set to avoid drift, use empty list if no columns
-	columns := make([]map[string]interface{}, 0)
-	if dataset.Columns != nil && len(dataset.Columns) > 0 {
-		columns = make([]map[string]interface{}, len(dataset.Columns))
-		for i, col := range dataset.Columns {
-			columns[i] = map[string]interface{}{
-				"name":      col.Name,
-				"data_type": col.DataType,
-			}
-		}
-	}
-	if err := d.Set("columns", columns); err != nil {
-		return diag.Errorf("failed to set columns: %v", err)
+	columns := make([]interface{}, 0, len(dataset.Columns))
+	for _, col := range dataset.Columns {
+		columns = append(columns, map[string]interface{}{
+			"name":      col.Name,
+			"data_type": col.DataType,
+		})
 	}
+	result["columns"] = columns
 
-	return nil
+	return result
 }
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func dataSourceDatasets() *schema.Resource {
+	item := listItemSchema(dataSourceDataset().Schema, "workspace_id")
+	item.Schema["id"].Description = "The ID of the dataset."
+
+	return &schema.Resource{
+		Description: "Use this data source to list the Census SQL datasets in a workspace, optionally filtered by name or source connection.",
+
+		ReadContext: dataSourceDatasetsRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the workspace to list datasets from.",
+			},
+			"name_regex": nameRegexSchema("Only return datasets whose name matches this regular expression."),
+			"source_connection_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return datasets that query this source connection.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching datasets.",
+			},
+			"datasets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        item,
+				Description: "The matching datasets, ordered by ID.",
+			},
+		},
+	}
+}
+
+func dataSourceDatasetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	filter, err := listFilterFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workspaceId := d.Get("workspace_id").(string)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	datasets, err := apiClient.ListAllDatasetsWithToken(ctx, nil, workspaceToken)
+	if err != nil {
+		return diag.Errorf("failed to list datasets: %v", err)
+	}
+
+	ids := make([]string, 0, len(datasets))
+	items := make([]interface{}, 0, len(datasets))
+	for _, dataset := range FilterDatasets(datasets, filter) {
+		dataset := dataset
+		item := flattenDatasetForDataSource(&dataset)
+		item["id"] = strconv.Itoa(dataset.ID)
+		ids = append(ids, item["id"].(string))
+		items = append(items, item)
+	}
+
+	d.SetId(workspaceId)
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("failed to set ids: %v", err)
+	}
+	if err := d.Set("datasets", items); err != nil {
+		return diag.Errorf("failed to set datasets: %v", err)
+	}
+
+	return nil
+}
@@ -17,21 +17,13 @@ func dataSourceDestination() *schema.Resource {
 		ReadContext: dataSourceDestinationRead,
 
 		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of the destination to retrieve.",
-			},
+			"id": lookupIDSchema("destination", "name"),
 			"workspace_id": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The ID of the workspace this destination belongs to.",
 			},
-			"name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The name of the destination connection.",
-			},
+			"name": lookupNameSchema("The name of the destination connection.", "name"),
 			"type": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -69,27 +61,35 @@ func dataSourceDestination() *schema.Resource {
 func dataSourceDestinationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*client.Client)
 
-	id, err := strconv.Atoi(d.Get("id").(string))
+	id, byID, err := lookupID(d, "destination")
 	if err != nil {
-		return diag.Errorf("invalid destination ID: %s", d.Get("id").(string))
+		return diag.FromErr(err)
 	}
 
 	workspaceId := d.Get("workspace_id").(string)
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
-	// Get workspace token using personal access token
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.Errorf("failed to get workspace API key for workspace %d: %v", workspaceIdInt, err)
-	}
-
-	// Get the destination using the workspace token
-	destination, err := apiClient.GetDestinationWithToken(ctx, id, workspaceToken)
-	if err != nil {
-		return diag.FromErr(err)
+	var destination *client.Destination
+	if byID {
+		// Get the destination using the workspace token
+		destination, err = apiClient.GetDestinationWithToken(ctx, id, workspaceToken)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		destinations, err := apiClient.ListAllDestinationsWithToken(ctx, nil, workspaceToken)
+		if err != nil {
+			return diag.Errorf("failed to list destinations: %v", err)
+		}
+		destination, err = FindByName(destinations, "destination", "name", d.Get("name").(string),
+			func(s client.Destination) string { return s.Name },
+			func(s client.Destination) int { return s.ID })
+		if err != nil {
+			return diag.Errorf("failed to look up destination in workspace %s: %v", workspaceId, err)
+		}
 	}
 
 	// Check if destination is nil (API returned successfully but with nil data)
@@ -99,16 +99,27 @@ func dataSourceDestinationRead(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(strconv.Itoa(destination.ID))
 	// Note: workspace_id is a Required input field, don't overwrite it with API response
-	d.Set("name", destination.Name)
-	d.Set("type", destination.Type)
-	d.Set("status", destination.Status)
-	d.Set("test_status", destination.TestStatus)
-	d.Set("created_at", destination.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
-	d.Set("updated_at", destination.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	if err := setDataSourceAttributes(d, flattenDestinationForDataSource(destination)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenDestinationForDataSource converts a destination into the computed attributes shared by census_destination and census_destinations
+func flattenDestinationForDataSource(destination *client.Destination) map[string]interface{} {
+	result := map[string]interface{}{
+		"name":        destination.Name,
+		"type":        destination.Type,
+		"status":      destination.Status,
+		"test_status": destination.TestStatus,
+		"created_at":  destination.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		"updated_at":  destination.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
 
 	if destination.LastTested != nil {
-		d.Set("last_tested", destination.LastTested.Format("2006-01-02T15:04:05Z07:00"))
+		result["last_tested"] = destination.LastTested.Format("2006-01-02T15:04:05Z07:00")
 	}
 
-	return nil
+	return result
 }
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func dataSourceDestinationTypes() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the destination connector types available to a workspace, including the configuration fields each one requires.",
+
+		ReadContext: dataSourceDestinationTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the workspace whose destination catalog to read.",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the destination type with this service name (e.g., salesforce, hubspot).",
+			},
+			"service_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The service names of the returned destination types.",
+			},
+			"destination_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The available destination types, ordered by service name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value to use as the `type` of a `census_destination`.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The display name of the destination type.",
+						},
+						"documentation_slug": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The slug of the Census documentation page for the destination type.",
+						},
+						"supports_test": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether connections of this type can be tested.",
+						},
+						"creatable_via_api": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether destinations of this type can be created through the API.",
+						},
+						"configuration_fields": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        catalogFieldSchema(),
+							Description: "The fields accepted in `connection_config`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDestinationTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	workspaceId := d.Get("workspace_id").(string)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	connectors, err := apiClient.GetConnectors(ctx, workspaceToken)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(connectors, func(i, j int) bool { return connectors[i].ServiceName < connectors[j].ServiceName })
+
+	serviceName := d.Get("service_name").(string)
+	names := make([]string, 0, len(connectors))
+	items := make([]interface{}, 0, len(connectors))
+	for _, connector := range connectors {
+		if serviceName != "" && connector.ServiceName != serviceName {
+			continue
+		}
+		names = append(names, connector.ServiceName)
+		items = append(items, map[string]interface{}{
+			"service_name":         connector.ServiceName,
+			"label":                connector.Label,
+			"documentation_slug":   connector.DocumentationSlug,
+			"supports_test":        connector.SupportsTest,
+			"creatable_via_api":    connector.CreatableViaAPI,
+			"configuration_fields": flattenCatalogFields(ConnectorCatalogFields(connector)),
+		})
+	}
+
+	if serviceName != "" && len(items) == 0 {
+		return diag.Errorf("destination type %q is not available in workspace %s", serviceName, workspaceId)
+	}
+
+	d.SetId(workspaceId)
+	if err := d.Set("service_names", names); err != nil {
+		return diag.Errorf("failed to set service_names: %v", err)
+	}
+	if err := d.Set("destination_types", items); err != nil {
+		return diag.Errorf("failed to set destination_types: %v", err)
+	}
+
+	return nil
+}
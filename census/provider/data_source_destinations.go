@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func dataSourceDestinations() *schema.Resource {
+	item := listItemSchema(dataSourceDestination().Schema, "workspace_id")
+	item.Schema["id"].Description = "The ID of the destination."
+
+	return &schema.Resource{
+		Description: "Use this data source to list the Census destinations in a workspace, optionally filtered by name, type or status.",
+
+		ReadContext: dataSourceDestinationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the workspace to list destinations from.",
+			},
+			"name_regex": nameRegexSchema("Only return destinations whose name matches this regular expression."),
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return destinations of this type (e.g., salesforce, hubspot).",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return destinations with this status.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching destinations.",
+			},
+			"destinations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        item,
+				Description: "The matching destinations, ordered by ID.",
+			},
+		},
+	}
+}
+
+func dataSourceDestinationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	filter, err := listFilterFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workspaceId := d.Get("workspace_id").(string)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	destinations, err := apiClient.ListAllDestinationsWithToken(ctx, nil, workspaceToken)
+	if err != nil {
+		return diag.Errorf("failed to list destinations: %v", err)
+	}
+
+	ids := make([]string, 0, len(destinations))
+	items := make([]interface{}, 0, len(destinations))
+	for _, destination := range FilterDestinations(destinations, filter) {
+		destination := destination
+		item := flattenDestinationForDataSource(&destination)
+		item["id"] = strconv.Itoa(destination.ID)
+		ids = append(ids, item["id"].(string))
+		items = append(items, item)
+	}
+
+	d.SetId(workspaceId)
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("failed to set ids: %v", err)
+	}
+	if err := d.Set("destinations", items); err != nil {
+		return diag.Errorf("failed to set destinations: %v", err)
+	}
+
+	return nil
+}
@@ -17,21 +17,13 @@ func dataSourceSource() *schema.Resource {
 		ReadContext: dataSourceSourceRead,
 
 		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of the source to retrieve.",
-			},
+			"id": lookupIDSchema("source", "name"),
 			"workspace_id": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The ID of the workspace this source belongs to.",
 			},
-			"name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The name of the source connection.",
-			},
+			"name": lookupNameSchema("The name of the source connection.", "name"),
 			"type": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -69,27 +61,35 @@ func dataSourceSource() *schema.Resource {
 func dataSourceSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*client.Client)
 
-	id, err := strconv.Atoi(d.Get("id").(string))
+	id, byID, err := lookupID(d, "source")
 	if err != nil {
-		return diag.Errorf("invalid source ID: %s", d.Get("id").(string))
+		return diag.FromErr(err)
 	}
 
 	workspaceId := d.Get("workspace_id").(string)
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
-	// Get workspace token using personal access token
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.Errorf("failed to get workspace API key for workspace %d: %v", workspaceIdInt, err)
-	}
-
-	// Get the source using the workspace token
-	source, err := apiClient.GetSourceWithToken(ctx, id, workspaceToken)
-	if err != nil {
-		return diag.FromErr(err)
+	var source *client.Source
+	if byID {
+		// Get the source using the workspace token
+		source, err = apiClient.GetSourceWithToken(ctx, id, workspaceToken)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		sources, err := apiClient.ListAllSourcesWithToken(ctx, nil, workspaceToken)
+		if err != nil {
+			return diag.Errorf("failed to list sources: %v", err)
+		}
+		source, err = FindByName(sources, "source", "name", d.Get("name").(string),
+			func(s client.Source) string { return s.Name },
+			func(s client.Source) int { return s.ID })
+		if err != nil {
+			return diag.Errorf("failed to look up source in workspace %s: %v", workspaceId, err)
+		}
 	}
 
 	// Check if source is nil (API returned successfully but with nil data)
@@ -99,16 +99,27 @@ func dataSourceSourceRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	d.SetId(strconv.Itoa(source.ID))
 	// Note: workspace_id is a Required input field, don't overwrite it with API response
-	d.Set("name", source.Name)
-	d.Set("type", source.Type)
-	d.Set("status", source.Status)
-	d.Set("test_status", source.TestStatus)
-	d.Set("created_at", source.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
-	d.Set("updated_at", source.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	if err := setDataSourceAttributes(d, flattenSourceForDataSource(source)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenSourceForDataSource converts a source into the computed attributes shared by census_source and census_sources
+func flattenSourceForDataSource(source *client.Source) map[string]interface{} {
+	result := map[string]interface{}{
+		"name":        source.Name,
+		"type":        source.Type,
+		"status":      source.Status,
+		"test_status": source.TestStatus,
+		"created_at":  source.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		"updated_at":  source.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
 
 	if source.LastTested != nil {
-		d.Set("last_tested", source.LastTested.Format("2006-01-02T15:04:05Z07:00"))
+		result["last_tested"] = source.LastTested.Format("2006-01-02T15:04:05Z07:00")
 	}
 
-	return nil
+	return result
 }
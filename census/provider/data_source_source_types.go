@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func dataSourceSourceTypes() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the source connector types available to a workspace, including the configuration fields each one requires.",
+
+		ReadContext: dataSourceSourceTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the workspace whose source catalog to read.",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the source type with this service name (e.g., snowflake, big_query).",
+			},
+			"service_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The service names of the returned source types.",
+			},
+			"source_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The available source types, ordered by service name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value to use as the `type` of a `census_source`.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The display name of the source type.",
+						},
+						"documentation_slug": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The slug of the Census documentation page for the source type.",
+						},
+						"supported_sync_engines": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The sync engines the source type supports (e.g., basic, advanced).",
+						},
+						"creatable_via_api": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether sources of this type can be created through the API.",
+						},
+						"editable_via_api": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether sources of this type can be updated through the API.",
+						},
+						"configuration_fields": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        catalogFieldSchema(),
+							Description: "The fields accepted in `connection_config`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSourceTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	workspaceId := d.Get("workspace_id").(string)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	sourceTypes, err := apiClient.GetSourceTypes(ctx, workspaceToken)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(sourceTypes, func(i, j int) bool { return sourceTypes[i].ServiceName < sourceTypes[j].ServiceName })
+
+	serviceName := d.Get("service_name").(string)
+	names := make([]string, 0, len(sourceTypes))
+	items := make([]interface{}, 0, len(sourceTypes))
+	for _, sourceType := range sourceTypes {
+		if serviceName != "" && sourceType.ServiceName != serviceName {
+			continue
+		}
+		names = append(names, sourceType.ServiceName)
+		items = append(items, map[string]interface{}{
+			"service_name":           sourceType.ServiceName,
+			"label":                  sourceType.Label,
+			"documentation_slug":     sourceType.DocumentationSlug,
+			"supported_sync_engines": sourceType.SupportedSyncEngines,
+			"creatable_via_api":      sourceType.CreatableViaAPI,
+			"editable_via_api":       sourceType.EditableViaAPI,
+			"configuration_fields":   flattenCatalogFields(SourceTypeCatalogFields(sourceType)),
+		})
+	}
+
+	if serviceName != "" && len(items) == 0 {
+		return diag.Errorf("source type %q is not available in workspace %s", serviceName, workspaceId)
+	}
+
+	d.SetId(workspaceId)
+	if err := d.Set("service_names", names); err != nil {
+		return diag.Errorf("failed to set service_names: %v", err)
+	}
+	if err := d.Set("source_types", items); err != nil {
+		return diag.Errorf("failed to set source_types: %v", err)
+	}
+
+	return nil
+}
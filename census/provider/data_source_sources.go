@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func dataSourceSources() *schema.Resource {
+	item := listItemSchema(dataSourceSource().Schema, "workspace_id")
+	item.Schema["id"].Description = "The ID of the source."
+
+	return &schema.Resource{
+		Description: "Use this data source to list the Census sources in a workspace, optionally filtered by name, type or status.",
+
+		ReadContext: dataSourceSourcesRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the workspace to list sources from.",
+			},
+			"name_regex": nameRegexSchema("Only return sources whose name matches this regular expression."),
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return sources of this type (e.g., snowflake, bigquery).",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return sources with this status.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching sources.",
+			},
+			"sources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        item,
+				Description: "The matching sources, ordered by ID.",
+			},
+		},
+	}
+}
+
+func dataSourceSourcesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	filter, err := listFilterFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workspaceId := d.Get("workspace_id").(string)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	sources, err := apiClient.ListAllSourcesWithToken(ctx, nil, workspaceToken)
+	if err != nil {
+		return diag.Errorf("failed to list sources: %v", err)
+	}
+
+	ids := make([]string, 0, len(sources))
+	items := make([]interface{}, 0, len(sources))
+	for _, source := range FilterSources(sources, filter) {
+		source := source
+		item := flattenSourceForDataSource(&source)
+		item["id"] = strconv.Itoa(source.ID)
+		ids = append(ids, item["id"].(string))
+		items = append(items, item)
+	}
+
+	d.SetId(workspaceId)
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("failed to set ids: %v", err)
+	}
+	if err := d.Set("sources", items); err != nil {
+		return diag.Errorf("failed to set sources: %v", err)
+	}
+
+	return nil
+}
@@ -18,21 +18,13 @@ func dataSourceSync() *schema.Resource {
 		ReadContext: dataSourceSyncRead,
 
 		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of the sync.",
-			},
+			"id": lookupIDSchema("sync", "label"),
 			"workspace_id": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The ID of the workspace this sync belongs to.",
 			},
-			"label": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The name/label of the sync.",
-			},
+			"label": lookupNameSchema("The name/label of the sync.", "label"),
 			"source_attributes": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -257,25 +249,34 @@ func dataSourceSync() *schema.Resource {
 func dataSourceSyncRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*client.Client)
 
-	syncID, err := strconv.Atoi(d.Get("id").(string))
+	syncID, byID, err := lookupID(d, "sync")
 	if err != nil {
-		return diag.Errorf("invalid sync ID: %s", d.Get("id").(string))
+		return diag.FromErr(err)
 	}
 
 	workspaceId := d.Get("workspace_id").(string)
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	sync, err := apiClient.GetSyncWithToken(ctx, syncID, workspaceToken)
-	if err != nil {
-		return diag.FromErr(err)
+	var sync *client.Sync
+	if byID {
+		sync, err = apiClient.GetSyncWithToken(ctx, syncID, workspaceToken)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		syncs, err := apiClient.ListAllSyncsWithToken(ctx, nil, workspaceToken)
+		if err != nil {
+			return diag.Errorf("failed to list syncs: %v", err)
+		}
+		sync, err = FindByName(syncs, "sync", "label", d.Get("label").(string),
+			func(s client.Sync) string { return s.Label },
+			func(s client.Sync) int { return s.ID })
+		if err != nil {
+			return diag.Errorf("failed to look up sync in workspace %s: %v", workspaceId, err)
+		}
 	}
 
 	// Check if sync is nil (API returned successfully but with nil data)
@@ -285,39 +286,40 @@ func dataSourceSyncRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	d.SetId(strconv.Itoa(sync.ID))
 	d.Set("workspace_id", workspaceId)
-	d.Set("label", sync.Label)
-	d.Set("status", sync.Status)
-	d.Set("paused", sync.Paused)
-	d.Set("created_at", sync.CreatedAt.Format("2006-01-02T15:04:05Z"))
-	d.Set("updated_at", sync.UpdatedAt.Format("2006-01-02T15:04:05Z"))
+	if err := setDataSourceAttributes(d, flattenSyncForDataSource(sync)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenSyncForDataSource converts a sync into the computed attributes shared by census_sync and census_syncs
+func flattenSyncForDataSource(sync *client.Sync) map[string]interface{} {
+	result := map[string]interface{}{
+		"label":                  sync.Label,
+		"status":                 sync.Status,
+		"paused":                 sync.Paused,
+		"created_at":             sync.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		"updated_at":             sync.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		"source_attributes":      flattenSourceAttributesForDataSource(sync.SourceAttributes),
+		"destination_attributes": flattenDestinationAttributesForDataSource(sync.DestinationAttributes),
+		"field_mapping":          FlattenFieldMappings(sync.FieldMappings),
+	}
 
 	if sync.LastRunAt != nil {
-		d.Set("last_run_at", sync.LastRunAt.Format("2006-01-02T15:04:05Z"))
+		result["last_run_at"] = sync.LastRunAt.Format("2006-01-02T15:04:05Z")
 	}
 	if sync.NextRunAt != nil {
-		d.Set("next_run_at", sync.NextRunAt.Format("2006-01-02T15:04:05Z"))
+		result["next_run_at"] = sync.NextRunAt.Format("2006-01-02T15:04:05Z")
 	}
 	if sync.LastRunID != nil {
-		d.Set("last_run_id", *sync.LastRunID)
-	}
-
-	// Set complex attributes
-	if err := d.Set("source_attributes", flattenSourceAttributesForDataSource(sync.SourceAttributes)); err != nil {
-		return diag.Errorf("failed to set source_attributes: %v", err)
-	}
-	if err := d.Set("destination_attributes", flattenDestinationAttributesForDataSource(sync.DestinationAttributes)); err != nil {
-		return diag.Errorf("failed to set destination_attributes: %v", err)
-	}
-	if err := d.Set("field_mapping", FlattenFieldMappings(sync.FieldMappings)); err != nil {
-		return diag.Errorf("failed to set field_mapping: %v", err)
+		result["last_run_id"] = *sync.LastRunID
 	}
 	if sync.Mode != nil {
-		if err := d.Set("run_mode", FlattenRunMode(sync.Mode)); err != nil {
-			return diag.Errorf("failed to set run_mode: %v", err)
-		}
+		result["run_mode"] = FlattenRunMode(sync.Mode)
 	}
 
-	return nil
+	return result
 }
 
 // flattenSourceAttributesForDataSource converts API source_attributes map to Terraform TypeList format
@@ -330,14 +332,7 @@ func flattenSourceAttributesForDataSource(attrs map[string]interface{}) []interf
 
 	// Convert connection_id to string
 	if connID, ok := attrs["connection_id"]; ok {
-		switch v := connID.(type) {
-		case float64:
-			result["connection_id"] = strconv.FormatFloat(v, 'f', -1, 64)
-		case int:
-			result["connection_id"] = strconv.Itoa(v)
-		case string:
-			result["connection_id"] = v
-		}
+		result["connection_id"] = connectionIDString(connID)
 	}
 
 	// Serialize object as JSON string
@@ -365,14 +360,7 @@ func flattenDestinationAttributesForDataSource(attrs map[string]interface{}) []i
 
 	// Convert connection_id to string
 	if connID, ok := attrs["connection_id"]; ok {
-		switch v := connID.(type) {
-		case float64:
-			result["connection_id"] = strconv.FormatFloat(v, 'f', -1, 64)
-		case int:
-			result["connection_id"] = strconv.Itoa(v)
-		case string:
-			result["connection_id"] = v
-		}
+		result["connection_id"] = connectionIDString(connID)
 	}
 
 	// Set object as string
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func dataSourceSyncGraph() *schema.Resource {
+	edge := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"from": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the sync that runs first.",
+			},
+			"to": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the sync triggered when `from` completes.",
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to read the graph of `sync_sequence` triggers between the syncs in a workspace, for example to render a pipeline.",
+
+		ReadContext: dataSourceSyncGraphRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the workspace whose syncs to graph.",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Every sync in the workspace, ordered by ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the sync.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The label of the sync.",
+						},
+						"paused": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the sync is paused.",
+						},
+						"upstream_sync_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the sync whose completion triggers this one, or empty.",
+						},
+						"downstream_sync_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The IDs of the syncs this one triggers.",
+						},
+					},
+				},
+			},
+			"edges": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        edge,
+				Description: "The `sync_sequence` triggers between syncs in the workspace.",
+			},
+			"dangling_edges": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        edge,
+				Description: "Triggers that wait for a sync that no longer exists. These syncs are never triggered.",
+			},
+			"topological_order": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Every sync ID ordered so that each sync comes after the sync that triggers it.",
+			},
+		},
+	}
+}
+
+func dataSourceSyncGraphRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	workspaceId := d.Get("workspace_id").(string)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	syncs, err := apiClient.ListAllSyncsWithToken(ctx, nil, workspaceToken)
+	if err != nil {
+		return diag.Errorf("failed to list syncs: %v", err)
+	}
+
+	graph := BuildSyncGraph(syncs)
+	order, err := graph.TopologicalOrder()
+	if err != nil {
+		return diag.Errorf("failed to order syncs in workspace %s: %v", workspaceId, err)
+	}
+
+	downstream := make(map[int][]string)
+	edges := make([]interface{}, 0)
+	dangling := make([]interface{}, 0)
+	for _, edge := range graph.Edges() {
+		item := map[string]interface{}{
+			"from": strconv.Itoa(edge.From),
+			"to":   strconv.Itoa(edge.To),
+		}
+		if _, ok := graph.Nodes[edge.From]; !ok {
+			dangling = append(dangling, item)
+			continue
+		}
+		downstream[edge.From] = append(downstream[edge.From], strconv.Itoa(edge.To))
+		edges = append(edges, item)
+	}
+
+	ids := make([]int, 0, len(graph.Nodes))
+	for id := range graph.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	nodes := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		node := graph.Nodes[id]
+		upstream := ""
+		if upstreamID := graph.Upstream(id); upstreamID != 0 {
+			upstream = strconv.Itoa(upstreamID)
+		}
+		nodes = append(nodes, map[string]interface{}{
+			"id":                  strconv.Itoa(node.ID),
+			"label":               node.Label,
+			"paused":              node.Paused,
+			"upstream_sync_id":    upstream,
+			"downstream_sync_ids": downstream[id],
+		})
+	}
+
+	orderIDs := make([]string, len(order))
+	for i, id := range order {
+		orderIDs[i] = strconv.Itoa(id)
+	}
+
+	d.SetId(workspaceId)
+	if err := d.Set("nodes", nodes); err != nil {
+		return diag.Errorf("failed to set nodes: %v", err)
+	}
+	if err := d.Set("edges", edges); err != nil {
+		return diag.Errorf("failed to set edges: %v", err)
+	}
+	if err := d.Set("dangling_edges", dangling); err != nil {
+		return diag.Errorf("failed to set dangling_edges: %v", err)
+	}
+	if err := d.Set("topological_order", orderIDs); err != nil {
+		return diag.Errorf("failed to set topological_order: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func dataSourceSyncs() *schema.Resource {
+	item := listItemSchema(dataSourceSync().Schema, "workspace_id")
+	item.Schema["id"].Description = "The ID of the sync."
+
+	return &schema.Resource{
+		Description: "Use this data source to list the Census syncs in a workspace, optionally filtered by label, status, paused state or connection.",
+
+		ReadContext: dataSourceSyncsRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the workspace to list syncs from.",
+			},
+			"name_regex": nameRegexSchema("Only return syncs whose label matches this regular expression."),
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return syncs with this status.",
+			},
+			"paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return paused (`true`) or active (`false`) syncs.",
+			},
+			"source_connection_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return syncs reading from this source connection.",
+			},
+			"destination_connection_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return syncs writing to this destination connection.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching syncs.",
+			},
+			"syncs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        item,
+				Description: "The matching syncs, ordered by ID.",
+			},
+		},
+	}
+}
+
+func dataSourceSyncsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	filter, err := listFilterFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workspaceId := d.Get("workspace_id").(string)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	syncs, err := apiClient.ListAllSyncsWithToken(ctx, nil, workspaceToken)
+	if err != nil {
+		return diag.Errorf("failed to list syncs: %v", err)
+	}
+
+	ids := make([]string, 0, len(syncs))
+	items := make([]interface{}, 0, len(syncs))
+	for _, sync := range FilterSyncs(syncs, filter) {
+		sync := sync
+		item := flattenSyncForDataSource(&sync)
+		item["id"] = strconv.Itoa(sync.ID)
+		ids = append(ids, item["id"].(string))
+		items = append(items, item)
+	}
+
+	d.SetId(workspaceId)
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("failed to set ids: %v", err)
+	}
+	if err := d.Set("syncs", items); err != nil {
+		return diag.Errorf("failed to set syncs: %v", err)
+	}
+
+	return nil
+}
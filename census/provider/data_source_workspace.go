@@ -67,13 +67,19 @@ func dataSourceWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	d.SetId(strconv.Itoa(workspace.ID))
-	d.Set("name", workspace.Name)
-	d.Set("organization_id", workspace.OrganizationID)
-	d.Set("created_at", workspace.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
-
-	if err := d.Set("notification_emails", workspace.NotificationEmails); err != nil {
+	if err := setDataSourceAttributes(d, flattenWorkspaceForDataSource(workspace)); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
+
+// flattenWorkspaceForDataSource converts a workspace into the computed attributes shared by census_workspace and census_workspaces
+func flattenWorkspaceForDataSource(workspace *client.Workspace) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                workspace.Name,
+		"organization_id":     workspace.OrganizationID,
+		"created_at":          workspace.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		"notification_emails": workspace.NotificationEmails,
+	}
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func dataSourceWorkspaces() *schema.Resource {
+	item := listItemSchema(dataSourceWorkspace().Schema)
+	item.Schema["id"].Description = "The ID of the workspace."
+
+	return &schema.Resource{
+		Description: "Use this data source to list the Census workspaces in the organization, optionally filtered by name.",
+
+		ReadContext: dataSourceWorkspacesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": nameRegexSchema("Only return workspaces whose name matches this regular expression."),
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching workspaces.",
+			},
+			"workspaces": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        item,
+				Description: "The matching workspaces, ordered by ID.",
+			},
+		},
+	}
+}
+
+func dataSourceWorkspacesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	filter, err := listFilterFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workspaces, err := apiClient.ListAllWorkspaces(ctx, nil)
+	if err != nil {
+		return diag.Errorf("failed to list workspaces: %v", err)
+	}
+
+	ids := make([]string, 0, len(workspaces))
+	items := make([]interface{}, 0, len(workspaces))
+	for _, workspace := range FilterWorkspaces(workspaces, filter) {
+		workspace := workspace
+		item := flattenWorkspaceForDataSource(&workspace)
+		item["id"] = strconv.Itoa(workspace.ID)
+		ids = append(ids, item["id"].(string))
+		items = append(items, item)
+	}
+
+	d.SetId("workspaces")
+	if err := d.Set("ids", ids); err != nil {
+		return diag.Errorf("failed to set ids: %v", err)
+	}
+	if err := d.Set("workspaces", items); err != nil {
+		return diag.Errorf("failed to set workspaces: %v", err)
+	}
+
+	return nil
+}
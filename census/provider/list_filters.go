@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// ListFilter holds the optional filters of the plural data sources. Zero values match everything.
+type ListFilter struct {
+	NameRegex               *regexp.Regexp
+	Type                    string
+	Status                  string
+	Paused                  *bool
+	SourceConnectionID      string
+	DestinationConnectionID string
+}
+
+func (f ListFilter) matchName(name string) bool {
+	return f.NameRegex == nil || f.NameRegex.MatchString(name)
+}
+
+func (f ListFilter) matchType(objectType string) bool {
+	return f.Type == "" || strings.EqualFold(f.Type, objectType)
+}
+
+func (f ListFilter) matchStatus(status string) bool {
+	return f.Status == "" || strings.EqualFold(f.Status, status)
+}
+
+// FilterSyncs returns the syncs whose label, status, paused flag and connections match the filter
+func FilterSyncs(syncs []client.Sync, f ListFilter) []client.Sync {
+	var result []client.Sync
+	for _, sync := range syncs {
+		if !f.matchName(sync.Label) || !f.matchStatus(sync.Status) {
+			continue
+		}
+		if f.Paused != nil && *f.Paused != sync.Paused {
+			continue
+		}
+		if f.SourceConnectionID != "" && f.SourceConnectionID != connectionIDString(sync.SourceAttributes["connection_id"]) {
+			continue
+		}
+		if f.DestinationConnectionID != "" && f.DestinationConnectionID != connectionIDString(sync.DestinationAttributes["connection_id"]) {
+			continue
+		}
+		result = append(result, sync)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// FilterSources returns the sources whose name, type and status match the filter
+func FilterSources(sources []client.Source, f ListFilter) []client.Source {
+	var result []client.Source
+	for _, source := range sources {
+		if f.matchName(source.Name) && f.matchType(source.Type) && f.matchStatus(source.Status) {
+			result = append(result, source)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// FilterDestinations returns the destinations whose name, type and status match the filter
+func FilterDestinations(destinations []client.Destination, f ListFilter) []client.Destination {
+	var result []client.Destination
+	for _, destination := range destinations {
+		if f.matchName(destination.Name) && f.matchType(destination.Type) && f.matchStatus(destination.Status) {
+			result = append(result, destination)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// FilterDatasets returns the datasets whose name, type and source connection match the filter
+func FilterDatasets(datasets []client.Dataset, f ListFilter) []client.Dataset {
+	var result []client.Dataset
+	for _, dataset := range datasets {
+		if !f.matchName(dataset.Name) || !f.matchType(dataset.Type) {
+			continue
+		}
+		if f.SourceConnectionID != "" && f.SourceConnectionID != strconv.Itoa(dataset.SourceID) {
+			continue
+		}
+		result = append(result, dataset)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// FilterWorkspaces returns the workspaces whose name matches the filter
+func FilterWorkspaces(workspaces []client.Workspace, f ListFilter) []client.Workspace {
+	var result []client.Workspace
+	for _, workspace := range workspaces {
+		if f.matchName(workspace.Name) {
+			result = append(result, workspace)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// listFilterFromResourceData reads whichever filter arguments the data source's schema defines
+func listFilterFromResourceData(d *schema.ResourceData) (ListFilter, error) {
+	var f ListFilter
+
+	if v, ok := d.GetOk("name_regex"); ok {
+		re, err := regexp.Compile(v.(string))
+		if err != nil {
+			return f, fmt.Errorf("invalid name_regex: %w", err)
+		}
+		f.NameRegex = re
+	}
+	if v, ok := d.GetOk("type"); ok {
+		f.Type = v.(string)
+	}
+	if v, ok := d.GetOk("status"); ok {
+		f.Status = v.(string)
+	}
+	// GetOk treats false as unset, so paused = false would otherwise match every sync
+	if v, ok := d.GetOkExists("paused"); ok {
+		paused := v.(bool)
+		f.Paused = &paused
+	}
+	if v, ok := d.GetOk("source_connection_id"); ok {
+		f.SourceConnectionID = v.(string)
+	}
+	if v, ok := d.GetOk("destination_connection_id"); ok {
+		f.DestinationConnectionID = v.(string)
+	}
+
+	return f, nil
+}
+
+// nameRegexSchema is the name_regex filter argument shared by the plural data sources
+func nameRegexSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsValidRegExp,
+		Description:  description,
+	}
+}
+
+// listItemSchema builds the element schema of a plural data source from the matching singular
+// data source, so both expose the same attributes. Every attribute becomes computed and omitted
+// attributes (such as workspace_id) are dropped.
+func listItemSchema(singular map[string]*schema.Schema, omit ...string) *schema.Resource {
+	item := computedOnly(singular)
+	for _, key := range omit {
+		delete(item, key)
+	}
+	return &schema.Resource{Schema: item}
+}
+
+func computedOnly(in map[string]*schema.Schema) map[string]*schema.Schema {
+	out := make(map[string]*schema.Schema, len(in))
+	for key, s := range in {
+		c := &schema.Schema{
+			Type:        s.Type,
+			Computed:    true,
+			Sensitive:   s.Sensitive,
+			Description: s.Description,
+			Set:         s.Set,
+		}
+		switch elem := s.Elem.(type) {
+		case *schema.Resource:
+			c.Elem = &schema.Resource{Schema: computedOnly(elem.Schema)}
+		case *schema.Schema:
+			c.Elem = &schema.Schema{Type: elem.Type}
+		}
+		out[key] = c
+	}
+	return out
+}
+
+// setDataSourceAttributes sets every attribute of a flattened object on the data source
+func setDataSourceAttributes(d *schema.ResourceData, attributes map[string]interface{}) error {
+	for key, value := range attributes {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// connectionIDString normalizes a connection_id from source or destination attributes to a string
+func connectionIDString(v interface{}) string {
+	switch id := v.(type) {
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(id)
+	case string:
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// syncLogSubsystem is the tflog subsystem used by the census_sync resource
+	syncLogSubsystem = "census_sync"
+	// syncLogLevelEnvVar sets the log level of the census_sync subsystem independently of TF_LOG_PROVIDER
+	syncLogLevelEnvVar = "TF_LOG_PROVIDER_CENSUS_SYNC"
+)
+
+// syncLogContext sets workspace_id and sync_id on the provider root logger, so they are also
+// attached to census_client request logs, and returns a context with the census_sync subsystem
+func syncLogContext(ctx context.Context, workspaceId, syncId string) context.Context {
+	if workspaceId != "" {
+		ctx = tflog.SetField(ctx, "workspace_id", workspaceId)
+	}
+	if syncId != "" {
+		ctx = tflog.SetField(ctx, "sync_id", syncId)
+	}
+	return tflog.NewSubsystem(ctx, syncLogSubsystem, tflog.WithLevelFromEnv(syncLogLevelEnvVar), tflog.WithRootFields())
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// FindByName returns the only item whose name equals name. kind and attribute are used in the
+// error, which lists the matching IDs when the name is ambiguous.
+func FindByName[T any](items []T, kind, attribute, name string, nameOf func(T) string, idOf func(T) int) (*T, error) {
+	return findByName(items, kind, attribute, name, "set id to choose one", nameOf, idOf)
+}
+
+// findByName is FindByName with hint, which tells the user how to pick one of several matches
+func findByName[T any](items []T, kind, attribute, name, hint string, nameOf func(T) string, idOf func(T) int) (*T, error) {
+	var matches []int
+	for i := range items {
+		if nameOf(items[i]) == name {
+			matches = append(matches, i)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no %s with %s %q found", kind, attribute, name)
+	case 1:
+		return &items[matches[0]], nil
+	}
+
+	ids := make([]string, 0, len(matches))
+	for _, i := range matches {
+		ids = append(ids, strconv.Itoa(idOf(items[i])))
+	}
+	return nil, fmt.Errorf("%d %ss with %s %q found (IDs %s); %s", len(matches), kind, attribute, name, strings.Join(ids, ", "), hint)
+}
+
+// lookupIDSchema is the id argument of data sources that can also be looked up by nameAttribute
+func lookupIDSchema(kind, nameAttribute string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", nameAttribute},
+		Description:  fmt.Sprintf("The ID of the %s to retrieve. Exactly one of `id` or `%s` must be set; looking up by %s searches every page and fails unless exactly one %s matches.", kind, nameAttribute, nameAttribute, kind),
+	}
+}
+
+// lookupNameSchema is the name (or label) argument of data sources that can be looked up by it instead of id
+func lookupNameSchema(description string, nameAttribute string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", nameAttribute},
+		Description:  description,
+	}
+}
+
+// lookupID returns the parsed id argument, or ok = false when the data source is looked up by name
+func lookupID(d *schema.ResourceData, kind string) (id int, ok bool, err error) {
+	v, ok := d.GetOk("id")
+	if !ok {
+		return 0, false, nil
+	}
+	id, err = strconv.Atoi(v.(string))
+	if err != nil {
+		return 0, true, fmt.Errorf("invalid %s ID: %s", kind, v.(string))
+	}
+	return id, true, nil
+}
+
+// importNamePrefix marks an import ID that names the resource instead of giving its ID
+const importNamePrefix = "name="
+
+// splitImportID splits an import ID of the form workspace_id:ref, where ref is the resource ID or
+// name=<name>. Names may contain colons.
+func splitImportID(id string) (workspaceId, ref string, ok bool) {
+	workspaceId, ref, ok = strings.Cut(id, ":")
+	if !ok || workspaceId == "" || ref == "" {
+		return "", "", false
+	}
+	return workspaceId, ref, true
+}
+
+// resolveImportRef returns the ID given by the ref of an import ID. A name=<name> ref is looked up
+// on every page of list and fails with the matching IDs unless exactly one item has that attribute.
+func resolveImportRef[T any](ctx context.Context, meta interface{}, workspaceId, ref, kind, attribute string,
+	list func(*client.Client, context.Context, *client.ListAllOptions, string) ([]T, error),
+	nameOf func(T) string, idOf func(T) int) (string, error) {
+	name, byName := strings.CutPrefix(ref, importNamePrefix)
+	if !byName {
+		if _, err := strconv.Atoi(ref); err != nil {
+			return "", fmt.Errorf("invalid %s ID %q. Use format: workspace_id:%s_id or workspace_id:%s<%s>", kind, ref, kind, importNamePrefix, attribute)
+		}
+		return ref, nil
+	}
+
+	workspaceIdInt, err := strconv.Atoi(workspaceId)
+	if err != nil {
+		return "", fmt.Errorf("invalid workspace ID: %s", workspaceId)
+	}
+	apiClient := meta.(*client.Client)
+	workspaceToken, err := apiClient.GetCachedWorkspaceAPIKey(ctx, workspaceIdInt)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace API key for workspace %d: %w", workspaceIdInt, err)
+	}
+
+	items, err := list(apiClient, ctx, nil, workspaceToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to list %ss: %w", kind, err)
+	}
+	hint := fmt.Sprintf("import with workspace_id:%s_id to choose one", kind)
+	item, err := findByName(items, kind, attribute, name, hint, nameOf, idOf)
+	if err != nil {
+		return "", fmt.Errorf("failed to import %s from workspace %s: %w", kind, workspaceId, err)
+	}
+	return strconv.Itoa(idOf(*item)), nil
+}
@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
-	"regexp"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +20,20 @@ const (
 	DefaultBaseURL = "https://app.getcensus.com/api/v1"
 )
 
+// Regions lists the Census regions the provider can connect to, in the order they are documented
+var Regions = []string{"us", "eu"}
+
+// regionBaseURLs maps each of Regions to the base URL of its API
+var regionBaseURLs = map[string]string{
+	"us": DefaultBaseURL,
+	"eu": "https://app-eu.getcensus.com/api/v1",
+}
+
+// RegionList formats Regions for help text, e.g. "us or eu"
+func RegionList() string {
+	return strings.Join(Regions[:len(Regions)-1], ", ") + " or " + Regions[len(Regions)-1]
+}
+
 // Provider returns the Census Terraform provider
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -30,14 +46,11 @@ func Provider() *schema.Provider {
 				Description: "Personal access token for Census APIs. Used for all operations including dynamic workspace token retrieval. Can also be set via CENSUS_PERSONAL_ACCESS_TOKEN environment variable.",
 			},
 			"region": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  DefaultRegion,
-				ValidateFunc: validation.StringMatch(
-					regexp.MustCompile(`^(us|eu)$`),
-					"region must be either 'us' or 'eu'",
-				),
-				Description: "Census region to use (us or eu). Defaults to 'us'.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      DefaultRegion,
+				ValidateFunc: validation.StringInSlice(Regions, false),
+				Description:  "Census region to use (" + RegionList() + "). Defaults to 'us'.",
 			},
 			"base_url": {
 				Type:        schema.TypeString,
@@ -45,6 +58,20 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("CENSUS_BASE_URL", ""),
 				Description: "Base URL for Census API. If not provided, will be determined based on region. Can also be set via CENSUS_BASE_URL environment variable.",
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CENSUS_MAX_RETRIES", client.DefaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times a request is retried after a rate limit (429), a 502/503/504 response or a dropped connection. Only rate limited requests are retried for non-idempotent methods. Set to 0 to disable retries. Defaults to 3. Can also be set via CENSUS_MAX_RETRIES environment variable.",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CENSUS_RETRY_MAX_WAIT", int(client.DefaultRetryMaxWait/time.Second)),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of seconds to wait between retries, including waits requested by a Retry-After header. Defaults to 30. Can also be set via CENSUS_RETRY_MAX_WAIT environment variable.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"census_workspace":   resourceWorkspace(),
@@ -52,24 +79,53 @@ func Provider() *schema.Provider {
 			"census_destination": resourceDestination(),
 			"census_sync":        resourceSync(),
 			"census_dataset":     resourceDataset(),
+			"census_sync_run":    resourceSyncRun(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"census_workspace":   dataSourceWorkspace(),
-			"census_source":      dataSourceSource(),
-			"census_destination": dataSourceDestination(),
-			"census_sync":        dataSourceSync(),
-			"census_dataset":     dataSourceDataset(),
+			"census_workspace":    dataSourceWorkspace(),
+			"census_source":       dataSourceSource(),
+			"census_destination":  dataSourceDestination(),
+			"census_sync":         dataSourceSync(),
+			"census_dataset":      dataSourceDataset(),
+			"census_workspaces":   dataSourceWorkspaces(),
+			"census_sources":      dataSourceSources(),
+			"census_destinations": dataSourceDestinations(),
+			"census_syncs":        dataSourceSyncs(),
+			"census_datasets":     dataSourceDatasets(),
+
+			"census_source_types":      dataSourceSourceTypes(),
+			"census_destination_types": dataSourceDestinationTypes(),
+
+			"census_sync_graph": dataSourceSyncGraph(),
 		},
 		ConfigureContextFunc: configure,
 	}
 }
 
+// ProviderWithHTTPClient returns the Census Terraform provider with an API client that sends its
+// requests through httpClient, such as one that records or replays them in tests
+func ProviderWithHTTPClient(httpClient *http.Client) *schema.Provider {
+	p := Provider()
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return configureClient(ctx, d, httpClient)
+	}
+	return p
+}
+
 func configure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return configureClient(ctx, d, nil)
+}
+
+// configureClient builds the API client from the provider configuration. A nil httpClient uses the
+// client's default.
+func configureClient(ctx context.Context, d *schema.ResourceData, httpClient *http.Client) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	personalToken := d.Get("personal_access_token").(string)
 	region := d.Get("region").(string)
 	baseURL := d.Get("base_url").(string)
+	maxRetries := d.Get("max_retries").(int)
+	retryMaxWait := d.Get("retry_max_wait").(int)
 
 	// Validate that personal access token is provided
 	if personalToken == "" {
@@ -78,12 +134,8 @@ func configure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.D
 
 	// Determine base URL if not explicitly provided
 	if baseURL == "" {
-		switch region {
-		case "us":
-			baseURL = "https://app.getcensus.com/api/v1"
-		case "eu":
-			baseURL = "https://app-eu.getcensus.com/api/v1"
-		default:
+		var ok bool
+		if baseURL, ok = regionBaseURLs[region]; !ok {
 			return nil, diag.Errorf("unsupported region: %s", region)
 		}
 	}
@@ -93,6 +145,9 @@ func configure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.D
 		WorkspaceAccessToken: "", // No longer used - workspace tokens are fetched dynamically
 		BaseURL:              baseURL,
 		Region:               region,
+		HTTPClient:           httpClient,
+		MaxRetries:           maxRetries,
+		RetryMaxWait:         time.Duration(retryMaxWait) * time.Second,
 	}
 
 	client, err := client.NewClient(config)
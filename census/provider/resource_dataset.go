@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -114,18 +113,9 @@ func resourceDatasetCreate(ctx context.Context, d *schema.ResourceData, meta int
 
 	workspaceId := d.Get("workspace_id").(string)
 
-	// Get the workspace API key dynamically using the personal access token
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
-	}
-
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.Errorf("failed to get workspace API key for workspace %d: %v", workspaceIdInt, err)
-	}
-	if workspaceToken == "" {
-		return diag.Errorf("workspace API key is empty for workspace %d", workspaceIdInt)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Build description pointer
@@ -145,7 +135,7 @@ func resourceDatasetCreate(ctx context.Context, d *schema.ResourceData, meta int
 
 	dataset, err := apiClient.CreateDatasetWithToken(ctx, req, workspaceToken)
 	if err != nil {
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceDataset().Schema, nil)
 	}
 
 	d.SetId(strconv.Itoa(dataset.ID))
@@ -169,14 +159,9 @@ func resourceDatasetRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 	var dataset *client.Dataset
 	if workspaceId != "" {
-		workspaceIdInt, err := strconv.Atoi(workspaceId)
-		if err != nil {
-			return diag.Errorf("invalid workspace ID: %s", workspaceId)
-		}
-
-		workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-		if err != nil {
-			return diag.FromErr(err)
+		workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+		if diags.HasError() {
+			return diags
 		}
 
 		dataset, err = apiClient.GetDatasetWithToken(ctx, id, workspaceToken)
@@ -259,14 +244,9 @@ func resourceDatasetUpdate(ctx context.Context, d *schema.ResourceData, meta int
 
 	workspaceId := d.Get("workspace_id").(string)
 
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
-	}
-
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.FromErr(err)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
 	req := &client.UpdateDatasetRequest{}
@@ -289,7 +269,7 @@ func resourceDatasetUpdate(ctx context.Context, d *schema.ResourceData, meta int
 
 	_, err = apiClient.UpdateDatasetWithToken(ctx, id, req, workspaceToken)
 	if err != nil {
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceDataset().Schema, nil)
 	}
 
 	return resourceDatasetRead(ctx, d, meta)
@@ -304,14 +284,9 @@ func resourceDatasetDelete(ctx context.Context, d *schema.ResourceData, meta int
 	}
 
 	workspaceId := d.Get("workspace_id").(string)
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
-	}
-
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.FromErr(err)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
 	err = apiClient.DeleteDatasetWithToken(ctx, id, workspaceToken)
@@ -324,27 +299,28 @@ func resourceDatasetDelete(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func resourceDatasetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	// Support composite format: workspace_id:dataset_id
-	parts := strings.Split(d.Id(), ":")
-
-	if len(parts) == 2 {
-		// Format: workspace_id:dataset_id
-		workspaceId := parts[0]
-		datasetId := parts[1]
-
-		d.SetId(datasetId)
-		d.Set("workspace_id", workspaceId)
-
-		return []*schema.ResourceData{d}, nil
-	} else if len(parts) == 1 {
+	// Support composite formats: workspace_id:dataset_id and workspace_id:name=<name>
+	workspaceId, ref, ok := splitImportID(d.Id())
+	if !ok {
 		// Legacy format - provide helpful error
-		return nil, fmt.Errorf(`import requires workspace_id. Use format: workspace_id:dataset_id
+		return nil, fmt.Errorf(`import requires workspace_id. Use format: workspace_id:dataset_id or workspace_id:name=<name>
 
 Example:
   terraform import census_dataset.all_users 69962:789
+  terraform import census_dataset.all_users "69962:name=All users"
+
+Where 69962 is the workspace_id and 789 is the dataset_id. A name must match exactly one dataset in the workspace.`)
+	}
 
-Where 69962 is the workspace_id and 789 is the dataset_id.`)
+	datasetId, err := resolveImportRef(ctx, meta, workspaceId, ref, "dataset", "name", (*client.Client).ListAllDatasetsWithToken,
+		func(item client.Dataset) string { return item.Name },
+		func(item client.Dataset) int { return item.ID })
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("invalid import format. Use: workspace_id:dataset_id")
+	d.SetId(datasetId)
+	d.Set("workspace_id", workspaceId)
+
+	return []*schema.ResourceData{d}, nil
 }
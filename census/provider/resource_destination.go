@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,13 +24,7 @@ func resourceDestination() *schema.Resource {
 			StateContext: resourceDestinationImport,
 		},
 
-		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
-			// Force diff detection for sensitive connection_config changes
-			if d.HasChange("connection_config") {
-				d.SetNewComputed("updated_at")
-			}
-			return nil
-		},
+		CustomizeDiff: connectionCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -57,12 +50,16 @@ func resourceDestination() *schema.Resource {
 				Description: "The type of destination (e.g., salesforce, hubspot, postgres).",
 			},
 			"connection_config": {
-				Type:        schema.TypeMap,
-				Required:    true,
-				Sensitive:   true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "Connection configuration for the destination. Contents vary by destination type.",
+				Type:      schema.TypeMap,
+				Required:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+				// Secret fields are stored as salted hashes
+				DiffSuppressFunc: suppressHashedSecretDiff,
+				Description:      "Connection configuration for the destination. Contents vary by destination type.",
 			},
+			"connection_secrets":  connectionSecretsSchema("destination"),
+			"credentials_version": credentialsVersionSchema("destination"),
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -104,17 +101,15 @@ func resourceDestinationCreate(ctx context.Context, d *schema.ResourceData, meta
 	workspaceId := d.Get("workspace_id").(string)
 	name := d.Get("name").(string)
 	destinationType := d.Get("type").(string)
-	connectionConfig := expandConnectionConfig(d.Get("connection_config").(map[string]interface{}))
-
-	// Get the workspace API key dynamically using the personal access token
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
+	config, err := configuredConnectionConfig(d)
 	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
+		return diag.FromErr(err)
 	}
+	connectionConfig := connectionCredentials(d, config)
 
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.FromErr(err)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Validate destination credentials against connector requirements (now with pagination support)
@@ -134,11 +129,16 @@ func resourceDestinationCreate(ctx context.Context, d *schema.ResourceData, meta
 	// Use the dynamically retrieved workspace token
 	destination, err := apiClient.CreateDestinationWithToken(ctx, req, workspaceToken)
 	if err != nil {
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceDestination().Schema, connectionAPIFields)
 	}
 
 	d.SetId(strconv.Itoa(destination.ID))
 
+	secretFields, catalogErr := destinationSecretFields(ctx, apiClient, destinationType, workspaceToken)
+	if err := setStoredConnectionConfig(ctx, d, config, secretFields, catalogErr); err != nil {
+		return diag.Errorf("failed to set connection_config: %v", err)
+	}
+
 	// Explicitly set workspace_id from our input since API doesn't return it
 	d.Set("workspace_id", workspaceId)
 
@@ -167,14 +167,9 @@ func resourceDestinationRead(ctx context.Context, d *schema.ResourceData, meta i
 	workspaceId := d.Get("workspace_id").(string)
 	var destination *client.Destination
 	if workspaceId != "" {
-		workspaceIdInt, err := strconv.Atoi(workspaceId)
-		if err != nil {
-			return diag.Errorf("invalid workspace ID: %s", workspaceId)
-		}
-
-		workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-		if err != nil {
-			return diag.FromErr(err)
+		workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+		if diags.HasError() {
+			return diags
 		}
 
 		destination, err = apiClient.GetDestinationWithToken(ctx, id, workspaceToken)
@@ -213,9 +208,11 @@ func resourceDestinationRead(ctx context.Context, d *schema.ResourceData, meta i
 		d.Set("last_tested", destination.LastTested.Format("2006-01-02T15:04:05Z07:00"))
 	}
 
-	// Note: We don't set the connection config from the API response
-	// as it may not contain all the original values (especially secrets)
-	// Terraform will maintain the connection config from the configuration
+	// The API does not return secrets, so only public fields are refreshed. Secret fields keep their
+	// stored hashes, which are compared with the configured values when planning.
+	if err := readConnectionConfig(d, destination.Connection); err != nil {
+		return diag.Errorf("failed to set connection_config: %v", err)
+	}
 
 	return nil
 }
@@ -230,24 +227,24 @@ func resourceDestinationUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	// Always get workspace token for the update operation
 	workspaceId := d.Get("workspace_id").(string)
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
-	}
-
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.FromErr(err)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
 	req := &client.UpdateDestinationRequest{}
 
 	// Census API requires service_connection for all updates, so always include it
-	connectionConfig := expandConnectionConfig(d.Get("connection_config").(map[string]interface{}))
+	config, err := configuredConnectionConfig(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	connectionConfig := connectionCredentials(d, config)
+	connectionChanged := d.HasChanges("connection_config", "credentials_version")
 	destinationType := d.Get("type").(string)
 
 	// If connection changed, validate the new credentials
-	if d.HasChange("connection_config") {
+	if connectionChanged {
 		if err := apiClient.ValidateDestinationCredentials(ctx, destinationType, connectionConfig, workspaceToken); err != nil {
 			return diag.Errorf("destination credential validation failed: %v", err)
 		}
@@ -263,21 +260,22 @@ func resourceDestinationUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	_, err = apiClient.UpdateDestinationWithToken(ctx, id, req, workspaceToken)
 	if err != nil {
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceDestination().Schema, connectionAPIFields)
+	}
+
+	// Secret fields are stored as hashes once the update succeeds, so connectionChanged is read first
+	secretFields, catalogErr := destinationSecretFields(ctx, apiClient, destinationType, workspaceToken)
+	if err := setStoredConnectionConfig(ctx, d, config, secretFields, catalogErr); err != nil {
+		return diag.Errorf("failed to set connection_config: %v", err)
 	}
 
 	// Refresh objects if requested and connection changed
-	if d.HasChange("connection_config") && d.Get("auto_refresh_objects").(bool) {
+	if connectionChanged && d.Get("auto_refresh_objects").(bool) {
 		// We need the workspace token for refresh
 		workspaceId := d.Get("workspace_id").(string)
-		workspaceIdInt, err := strconv.Atoi(workspaceId)
-		if err != nil {
-			return diag.Errorf("invalid workspace ID: %s", workspaceId)
-		}
-
-		workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-		if err != nil {
-			return diag.FromErr(err)
+		workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+		if diags.HasError() {
+			return diags
 		}
 
 		refreshReq := &client.RefreshObjectsRequest{}
@@ -301,14 +299,9 @@ func resourceDestinationDelete(ctx context.Context, d *schema.ResourceData, meta
 	// Get workspace token dynamically if we have workspace_id
 	workspaceId := d.Get("workspace_id").(string)
 	if workspaceId != "" {
-		workspaceIdInt, err := strconv.Atoi(workspaceId)
-		if err != nil {
-			return diag.Errorf("invalid workspace ID: %s", workspaceId)
-		}
-
-		workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-		if err != nil {
-			return diag.FromErr(err)
+		workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+		if diags.HasError() {
+			return diags
 		}
 
 		err = apiClient.DeleteDestinationWithToken(ctx, id, workspaceToken)
@@ -329,27 +322,30 @@ func resourceDestinationDelete(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceDestinationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	// Support composite format: workspace_id:destination_id
-	parts := strings.Split(d.Id(), ":")
-
-	if len(parts) == 2 {
-		// Format: workspace_id:destination_id
-		workspaceId := parts[0]
-		destinationId := parts[1]
-
-		d.SetId(destinationId)
-		d.Set("workspace_id", workspaceId)
-
-		return []*schema.ResourceData{d}, nil
-	} else if len(parts) == 1 {
+	// Support composite formats: workspace_id:destination_id and workspace_id:name=<name>
+	workspaceId, ref, ok := splitImportID(d.Id())
+	if !ok {
 		// Legacy format - provide helpful error
-		return nil, fmt.Errorf(`import requires workspace_id. Use format: workspace_id:destination_id
+		return nil, fmt.Errorf(`import requires workspace_id. Use format: workspace_id:destination_id or workspace_id:name=<name>
 
 Example:
   terraform import census_destination.salesforce_crm 69962:456
+  terraform import census_destination.salesforce_crm "69962:name=Salesforce CRM"
 
-Where 69962 is the workspace_id and 456 is the destination_id.`)
+Where 69962 is the workspace_id and 456 is the destination_id. A name must match exactly one destination in the workspace.`)
 	}
 
-	return nil, fmt.Errorf("invalid import format. Use: workspace_id:destination_id")
+	destinationId, err := resolveImportRef(ctx, meta, workspaceId, ref, "destination", "name", (*client.Client).ListAllDestinationsWithToken,
+		func(item client.Destination) string { return item.Name },
+		func(item client.Destination) int { return item.ID })
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(destinationId)
+	d.Set("workspace_id", workspaceId)
+	// auto_refresh_objects is not returned by the API; its default is imported so the next plan is empty
+	d.Set("auto_refresh_objects", false)
+
+	return []*schema.ResourceData{d}, nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,13 +24,7 @@ func resourceSource() *schema.Resource {
 			StateContext: resourceSourceImport,
 		},
 
-		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
-			// Force diff detection for sensitive connection_config changes
-			if d.HasChange("connection_config") {
-				d.SetNewComputed("updated_at")
-			}
-			return nil
-		},
+		CustomizeDiff: connectionCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -57,12 +50,16 @@ func resourceSource() *schema.Resource {
 				Description: "The type of source (e.g., snowflake, bigquery, postgres).",
 			},
 			"connection_config": {
-				Type:        schema.TypeMap,
-				Required:    true,
-				Sensitive:   true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "Connection configuration for the source. Contents vary by source type.",
+				Type:      schema.TypeMap,
+				Required:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+				// Secret fields are stored as salted hashes
+				DiffSuppressFunc: suppressHashedSecretDiff,
+				Description:      "Connection configuration for the source. Contents vary by source type.",
 			},
+			"connection_secrets":  connectionSecretsSchema("source"),
+			"credentials_version": credentialsVersionSchema("source"),
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -104,20 +101,15 @@ func resourceSourceCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	workspaceId := d.Get("workspace_id").(string)
 	name := d.Get("name").(string)
 	sourceType := d.Get("type").(string)
-	connectionConfig := expandConnectionConfig(d.Get("connection_config").(map[string]interface{}))
-
-	// Get the workspace API key dynamically using the personal access token
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
+	config, err := configuredConnectionConfig(d)
 	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
+		return diag.FromErr(err)
 	}
+	connectionConfig := connectionCredentials(d, config)
 
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.Errorf("failed to get workspace API key for workspace %d: %v", workspaceIdInt, err)
-	}
-	if workspaceToken == "" {
-		return diag.Errorf("workspace API key is empty for workspace %d", workspaceIdInt)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Validate source credentials against source type requirements
@@ -137,11 +129,16 @@ func resourceSourceCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	// Use the dynamically retrieved workspace token
 	source, err := apiClient.CreateSourceWithToken(ctx, req, workspaceToken)
 	if err != nil {
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceSource().Schema, connectionAPIFields)
 	}
 
 	d.SetId(strconv.Itoa(source.ID))
 
+	secretFields, catalogErr := sourceSecretFields(ctx, apiClient, sourceType, workspaceToken)
+	if err := setStoredConnectionConfig(ctx, d, config, secretFields, catalogErr); err != nil {
+		return diag.Errorf("failed to set connection_config: %v", err)
+	}
+
 	// Explicitly set workspace_id from our input since API doesn't return it
 	d.Set("workspace_id", workspaceId)
 
@@ -169,14 +166,9 @@ func resourceSourceRead(ctx context.Context, d *schema.ResourceData, meta interf
 	workspaceId := d.Get("workspace_id").(string)
 	var source *client.Source
 	if workspaceId != "" {
-		workspaceIdInt, err := strconv.Atoi(workspaceId)
-		if err != nil {
-			return diag.Errorf("invalid workspace ID: %s", workspaceId)
-		}
-
-		workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-		if err != nil {
-			return diag.FromErr(err)
+		workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+		if diags.HasError() {
+			return diags
 		}
 
 		source, err = apiClient.GetSourceWithToken(ctx, id, workspaceToken)
@@ -222,9 +214,11 @@ Where 69962 is the workspace_id for marketing_prod workspace.`)
 		d.Set("last_tested", source.LastTested.Format("2006-01-02T15:04:05Z07:00"))
 	}
 
-	// Note: We don't set the connection config from the API response
-	// as it may not contain all the original values (especially secrets)
-	// Terraform will maintain the connection config from the configuration
+	// The API does not return secrets, so only public fields are refreshed. Secret fields keep their
+	// stored hashes, which are compared with the configured values when planning.
+	if err := readConnectionConfig(d, source.Connection); err != nil {
+		return diag.Errorf("failed to set connection_config: %v", err)
+	}
 
 	return nil
 }
@@ -240,21 +234,21 @@ func resourceSourceUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	// Get current values for structured update
 	name := d.Get("name").(string)
 	sourceType := d.Get("type").(string)
-	connectionConfig := expandConnectionConfig(d.Get("connection_config").(map[string]interface{}))
-	workspaceId := d.Get("workspace_id").(string)
-
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
+	config, err := configuredConnectionConfig(d)
 	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
+		return diag.FromErr(err)
 	}
+	connectionConfig := connectionCredentials(d, config)
+	connectionChanged := d.HasChanges("connection_config", "credentials_version")
+	workspaceId := d.Get("workspace_id").(string)
 
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.FromErr(err)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Always build complete connection structure for updates
-	if d.HasChange("connection_config") {
+	if connectionChanged {
 		if err := apiClient.ValidateSourceCredentials(ctx, sourceType, connectionConfig, workspaceToken); err != nil {
 			return diag.Errorf("source credential validation failed: %v", err)
 		}
@@ -271,11 +265,17 @@ func resourceSourceUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	// Use the workspace token for the update
 	_, err = apiClient.UpdateSourceWithToken(ctx, id, req, workspaceToken)
 	if err != nil {
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceSource().Schema, connectionAPIFields)
+	}
+
+	// Secret fields are stored as hashes once the update succeeds, so connectionChanged is read first
+	secretFields, catalogErr := sourceSecretFields(ctx, apiClient, sourceType, workspaceToken)
+	if err := setStoredConnectionConfig(ctx, d, config, secretFields, catalogErr); err != nil {
+		return diag.Errorf("failed to set connection_config: %v", err)
 	}
 
 	// Refresh tables if requested and connection changed
-	if d.HasChange("connection_config") && d.Get("auto_refresh_tables").(bool) {
+	if connectionChanged && d.Get("auto_refresh_tables").(bool) {
 		if err := apiClient.RefreshSourceTablesWithToken(ctx, id, workspaceToken); err != nil {
 			// Log the error but don't fail the update
 			return diag.Errorf("source updated successfully but table refresh failed: %v", err)
@@ -296,14 +296,9 @@ func resourceSourceDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	// Get workspace token dynamically if we have workspace_id
 	workspaceId := d.Get("workspace_id").(string)
 	if workspaceId != "" {
-		workspaceIdInt, err := strconv.Atoi(workspaceId)
-		if err != nil {
-			return diag.Errorf("invalid workspace ID: %s", workspaceId)
-		}
-
-		workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-		if err != nil {
-			return diag.FromErr(err)
+		workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+		if diags.HasError() {
+			return diags
 		}
 
 		err = apiClient.DeleteSourceWithToken(ctx, id, workspaceToken)
@@ -324,27 +319,30 @@ func resourceSourceDelete(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceSourceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	// Support composite format: workspace_id:source_id
-	parts := strings.Split(d.Id(), ":")
-
-	if len(parts) == 2 {
-		// Format: workspace_id:source_id
-		workspaceId := parts[0]
-		sourceId := parts[1]
-
-		d.SetId(sourceId)
-		d.Set("workspace_id", workspaceId)
-
-		return []*schema.ResourceData{d}, nil
-	} else if len(parts) == 1 {
+	// Support composite formats: workspace_id:source_id and workspace_id:name=<name>
+	workspaceId, ref, ok := splitImportID(d.Id())
+	if !ok {
 		// Legacy format - provide helpful error
-		return nil, fmt.Errorf(`import requires workspace_id. Use format: workspace_id:source_id
+		return nil, fmt.Errorf(`import requires workspace_id. Use format: workspace_id:source_id or workspace_id:name=<name>
 
 Example:
   terraform import census_source.snowflake_basic 69962:828
+  terraform import census_source.snowflake_basic "69962:name=Snowflake"
+
+Where 69962 is the workspace_id and 828 is the source_id. A name must match exactly one source in the workspace.`)
+	}
 
-Where 69962 is the workspace_id and 828 is the source_id.`)
+	sourceId, err := resolveImportRef(ctx, meta, workspaceId, ref, "source", "name", (*client.Client).ListAllSourcesWithToken,
+		func(item client.Source) string { return item.Name },
+		func(item client.Source) int { return item.ID })
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("invalid import format. Use: workspace_id:source_id")
+	d.SetId(sourceId)
+	d.Set("workspace_id", workspaceId)
+	// auto_refresh_tables is not returned by the API; its default is imported so the next plan is empty
+	d.Set("auto_refresh_tables", false)
+
+	return []*schema.ResourceData{d}, nil
 }
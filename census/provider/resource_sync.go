@@ -7,8 +7,9 @@ import (
 	"hash/fnv"
 	"reflect"
 	"strconv"
-	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -16,6 +17,14 @@ import (
 	"github.com/sutrolabs/terraform-provider-census/census/client"
 )
 
+// syncAPIFields maps sync API field names onto their schema attributes
+var syncAPIFields = map[string]string{
+	"alert_attributes": "alert",
+	"field_mappings":   "field_mapping",
+	"mappings":         "field_mapping",
+	"mode":             "run_mode",
+}
+
 func resourceSync() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages a Census data sync between a source and destination.",
@@ -25,478 +34,497 @@ func resourceSync() *schema.Resource {
 		UpdateContext: resourceSyncUpdate,
 		DeleteContext: resourceSyncDelete,
 
+		CustomizeDiff: resourceSyncCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSyncImport,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"id": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The ID of the sync.",
-			},
-			"workspace_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The ID of the workspace this sync belongs to.",
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceSyncV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceSyncStateUpgradeV0,
 			},
-			"label": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name/label of the sync.",
-			},
-			"source_attributes": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				Description: "Source-specific configuration (e.g., SQL query, table selection).",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"connection_id": {
-							Type:        schema.TypeInt,
-							Required:    true,
-							Description: "The ID of the source connection.",
-						},
-						"cohort_id": {
-							Type:        schema.TypeInt,
-							Optional:    true,
-							Description: "The ID of the cohort (for cohort sources). When specified, object.type should be 'cohort', object.id should be the cohort ID, and object.dataset_id should be the dataset ID.",
-						},
-						"object": {
-							Type:        schema.TypeList,
-							Required:    true,
-							MaxItems:    1,
-							Description: "Object configuration for the source.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"type": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "Type of object (table, dataset, model, etc.).",
-									},
-									"table_name": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "Table name (for table type).",
-									},
-									"table_schema": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "Table schema (for table type).",
-									},
-									"table_catalog": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "Table catalog (for table type).",
-									},
-									"id": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "Object ID (for dataset, model, segment, cohort, topic, etc.).",
-									},
-									"dataset_id": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "Dataset ID (for segment and cohort sources - the underlying dataset that the segment/cohort belongs to).",
-									},
+		},
+
+		Schema: resourceSyncSchema(),
+	}
+}
+
+func resourceSyncSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the sync.",
+		},
+		"workspace_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The ID of the workspace this sync belongs to.",
+		},
+		"label": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name/label of the sync.",
+		},
+		"source_attributes": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Source-specific configuration (e.g., SQL query, table selection).",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"connection_id": {
+						Type:        schema.TypeInt,
+						Required:    true,
+						Description: "The ID of the source connection.",
+					},
+					"cohort_id": {
+						Type:        schema.TypeInt,
+						Optional:    true,
+						Description: "The ID of the cohort (for cohort sources). When specified, object.type should be 'cohort', object.id should be the cohort ID, and object.dataset_id should be the dataset ID.",
+					},
+					"object": {
+						Type:        schema.TypeList,
+						Required:    true,
+						MaxItems:    1,
+						Description: "Object configuration for the source.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"type": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "Type of object (table, dataset, model, etc.).",
+								},
+								"table_name": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "Table name (for table type).",
+								},
+								"table_schema": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "Table schema (for table type).",
+								},
+								"table_catalog": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "Table catalog (for table type).",
+								},
+								"id": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "Object ID (for dataset, model, segment, cohort, topic, etc.).",
+								},
+								"dataset_id": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "Dataset ID (for segment and cohort sources - the underlying dataset that the segment/cohort belongs to).",
 								},
 							},
 						},
 					},
 				},
 			},
-			"destination_attributes": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				Description: "Destination-specific configuration (e.g., object, connection_id).",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"connection_id": {
-							Type:        schema.TypeInt,
-							Required:    true,
-							Description: "The ID of the destination connection.",
-						},
-						"object": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The destination object name (e.g., 'Contact' for Salesforce).",
-						},
-						"lead_union_insert_to": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Where to insert a union object (for Salesforce connections).",
-						},
+		},
+		"destination_attributes": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Destination-specific configuration (e.g., object, connection_id).",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"connection_id": {
+						Type:        schema.TypeInt,
+						Required:    true,
+						Description: "The ID of the destination connection.",
+					},
+					"object": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The destination object name (e.g., 'Contact' for Salesforce).",
+					},
+					"lead_union_insert_to": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Where to insert a union object (for Salesforce connections).",
 					},
 				},
 			},
-			"operation": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "How records are synced to the destination (upsert, append, mirror, etc.).",
-				ValidateFunc: validation.StringInSlice([]string{
-					"append", "insert", "mirror", "update", "upsert",
-				}, false),
-			},
-			"field_mapping": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "Field mappings between source and destination.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"from": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Source field name. Required for column mappings (type='direct'). Omit for constant, sync_metadata, segment_membership, and liquid_template mappings.",
-						},
-						"to": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Destination field name.",
-						},
-						"type": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "direct",
-							Description: "Mapping type: 'direct' (default), 'hash', 'constant', 'sync_metadata', 'segment_membership', or 'liquid_template'.",
-							ValidateFunc: validation.StringInSlice([]string{
-								"direct", "hash", "constant", "sync_metadata", "segment_membership", "liquid_template",
-							}, false),
-						},
-						"constant": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Constant value. Must also set type='constant'.",
-						},
-						"sync_metadata_key": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Sync metadata key (e.g., 'sync_run_id'). Must also set type='sync_metadata'.",
-						},
-						"segment_identify_by": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "How to identify segments (e.g., 'name'). Must also set type='segment_membership'.",
-						},
-						"liquid_template": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Liquid template for transforming data (e.g., '{{ record[\"field\"] | upcase }}'). Must also set type='liquid_template'.",
-						},
-						"is_primary_identifier": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "Whether this field is the primary identifier (sync key) for matching records. Exactly one field_mapping must have this set to true.",
-						},
-						"lookup_object": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Object to lookup for relationship mapping (e.g., 'user_list'). Used with lookup_field for foreign key lookups.",
-						},
-						"lookup_field": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Field to use for lookup in the lookup_object (e.g., 'id'). Used with lookup_object for foreign key lookups.",
-						},
-						"preserve_values": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "If true, preserves existing values in the destination field and prevents Census from overwriting them.",
-						},
-						"generate_field": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "If true, Census will generate/create this field in the destination.",
-						},
-						"sync_null_values": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-							Description: "If true (default), null values in the source will be synced to the destination. Set to false to skip syncing null values.",
-						},
-						"array_field": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "Whether the destination field is an array type. Only applicable when generate_field is true (for user-defined fields).",
-						},
-						"field_type": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The type of the destination field. Only applicable when generate_field is true (for user-defined fields). Available types depend on the destination.",
-						},
-						"follow_source_type": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     false,
-							Description: "Whether the destination field type should automatically follow changes to the source column type.",
-						},
+		},
+		"operation": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "How records are synced to the destination (upsert, append, mirror, etc.).",
+			ValidateFunc: validation.StringInSlice([]string{
+				"append", "insert", "mirror", "update", "upsert",
+			}, false),
+		},
+		// field_mapping uses the default hash of every argument. A Set func keyed on to alone would let
+		// the SDK skip diffing the set whenever the to fields are unchanged, so edits to from, type or
+		// any flag would never be planned.
+		"field_mapping": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Field mappings between source and destination. Mappings are unordered, and each destination field (`to`) can be mapped once.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"from": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Source field name. Required for column mappings (type='direct'). Omit for constant, sync_metadata, segment_membership, and liquid_template mappings.",
+					},
+					"to": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Destination field name.",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "direct",
+						Description: "Mapping type: 'direct' (default), 'hash', 'constant', 'sync_metadata', 'segment_membership', or 'liquid_template'.",
+						ValidateFunc: validation.StringInSlice([]string{
+							"direct", "hash", "constant", "sync_metadata", "segment_membership", "liquid_template",
+						}, false),
+					},
+					"constant": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Constant value. Must also set type='constant'.",
+					},
+					"sync_metadata_key": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Sync metadata key (e.g., 'sync_run_id'). Must also set type='sync_metadata'.",
+					},
+					"segment_identify_by": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "How to identify segments (e.g., 'name'). Must also set type='segment_membership'.",
+					},
+					"liquid_template": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Liquid template for transforming data (e.g., '{{ record[\"field\"] | upcase }}'). Must also set type='liquid_template'.",
+					},
+					"is_primary_identifier": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Whether this field is the primary identifier (sync key) for matching records. Exactly one field_mapping must have this set to true.",
+					},
+					"lookup_object": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Object to lookup for relationship mapping (e.g., 'user_list'). Used with lookup_field for foreign key lookups.",
+					},
+					"lookup_field": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Field to use for lookup in the lookup_object (e.g., 'id'). Used with lookup_object for foreign key lookups.",
+					},
+					"preserve_values": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "If true, preserves existing values in the destination field and prevents Census from overwriting them.",
+					},
+					"generate_field": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "If true, Census will generate/create this field in the destination.",
+					},
+					"sync_null_values": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+						Description: "If true (default), null values in the source will be synced to the destination. Set to false to skip syncing null values.",
+					},
+					"array_field": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Whether the destination field is an array type. Only applicable when generate_field is true (for user-defined fields).",
+					},
+					"field_type": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The type of the destination field. Only applicable when generate_field is true (for user-defined fields). Available types depend on the destination.",
+					},
+					"follow_source_type": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Whether the destination field type should automatically follow changes to the source column type.",
 					},
 				},
 			},
-			"sync_mode": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "DEPRECATED: This field is ignored. Use 'operation' instead.",
-				Deprecated:  "This field is ignored. The 'operation' field is used for sync mode instead.",
-			},
-			"paused": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Whether the sync is paused.",
-			},
-			"field_behavior": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				Description: "Specify how fields are synced. Use 'sync_all_properties' to automatically sync all properties from source to destination. " +
-					"Use 'specific_properties' (default) for manual field mappings only.",
-				ValidateFunc: validation.StringInSlice([]string{
-					"sync_all_properties", "specific_properties",
-				}, false),
-			},
-			"field_normalization": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				Description: "If field_behavior is 'sync_all_properties', specify how automatic field names should be normalized. " +
-					"Options: 'start_case', 'lower_case', 'upper_case', 'camel_case', 'snake_case', 'match_source_names'.",
-				ValidateFunc: validation.StringInSlice([]string{
-					"start_case", "lower_case", "upper_case", "camel_case", "snake_case", "match_source_names",
-				}, false),
-			},
-			"field_order": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				Description: "Specify how destination fields should be ordered. Options: 'alphabetical_column_name' (default) or 'mapping_order'. " +
-					"Only works on destinations that support field ordering.",
-				ValidateFunc: validation.StringInSlice([]string{
-					"alphabetical_column_name", "mapping_order",
-				}, false),
-			},
-			"sync_behavior_family": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				Description: "Specifies the behavior family for the sync. Use 'activateEvents' for event-based activation syncs " +
-					"(only supported for live syncs from Kafka/streaming sources). Use 'mapRecords' for record mapping syncs " +
-					"(not supported for live syncs from Materialize).",
-				ValidateFunc: validation.StringInSlice([]string{
-					"activateEvents", "mapRecords",
-				}, false),
-			},
-			"advanced_configuration": {
-				Type:             schema.TypeString,
-				Optional:         true,
-				Description:      "Advanced configuration options specific to the destination type as JSON. Use jsonencode() to specify values. Available options vary by destination.",
-				ValidateFunc:     validation.StringIsJSON,
-				DiffSuppressFunc: suppressEquivalentJSON,
-			},
-			"high_water_mark_attribute": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The name of the timestamp column to use for high water mark diffing strategy. When set, append syncs will use this column to identify new records instead of the default Census diff engine (using primary keys). Example: 'updated_at'.",
-			},
-			"historical_sync_operation": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				Description: "Specifies how the first sync should handle historical records when using append operation. " +
-					"Only applicable for append syncs. Options: 'skip_current_records' (skip existing records on first sync) or " +
-					"'backfill_all_records' (include all existing records on first sync).",
-				ValidateFunc: validation.StringInSlice([]string{
-					"skip_current_records", "backfill_all_records",
-				}, false),
-			},
-			"mirror_strategy": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				Description: "Specifies the strategy for mirror syncs. Only applicable when operation is 'mirror'. " +
-					"Options: 'sync_updates_and_deletes' (incrementally sync changes - most common), " +
-					"'sync_updates_and_nulls' (update records and set nulls without deletes), " +
-					"'upload_and_swap' (replace entire destination table with source snapshot).",
-				ValidateFunc: validation.StringInSlice([]string{
-					"sync_updates_and_deletes",
-					"sync_updates_and_nulls",
-					"upload_and_swap",
-				}, false),
-			},
-			"alert": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Alert configurations for the sync. Multiple alerts of different types can be configured.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:        schema.TypeInt,
-							Computed:    true,
-							Description: "The ID of the alert configuration (assigned by Census).",
-						},
-						"type": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Type of alert configuration.",
-							ValidateFunc: validation.StringInSlice([]string{
-								"FailureAlertConfiguration",
-								"InvalidRecordPercentAlertConfiguration",
-								"FullSyncTriggerAlertConfiguration",
-								"RecordCountDeviationAlertConfiguration",
-								"RuntimeAlertConfiguration",
-								"StatusAlertConfiguration",
-							}, false),
-						},
-						"send_for": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "first_time",
-							Description: "When to send alerts: 'first_time' (default) or 'every_time'.",
-							ValidateFunc: validation.StringInSlice([]string{
-								"first_time",
-								"every_time",
-							}, false),
-						},
-						"should_send_recovery": {
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-							Description: "Whether to send a recovery notification when the alert condition is resolved.",
-						},
-						"options": {
-							Type:        schema.TypeMap,
-							Optional:    true,
-							Description: "Alert-specific options (e.g., threshold for InvalidRecordPercentAlertConfiguration).",
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
+		},
+		"sync_mode": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "DEPRECATED: This field is ignored. Use 'operation' instead.",
+			Deprecated:  "This field is ignored. The 'operation' field is used for sync mode instead.",
+		},
+		"paused": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether the sync is paused.",
+		},
+		"field_behavior": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			Description: "Specify how fields are synced. Use 'sync_all_properties' to automatically sync all properties from source to destination. " +
+				"Use 'specific_properties' (default) for manual field mappings only.",
+			ValidateFunc: validation.StringInSlice([]string{
+				"sync_all_properties", "specific_properties",
+			}, false),
+		},
+		"field_normalization": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			Description: "If field_behavior is 'sync_all_properties', specify how automatic field names should be normalized. " +
+				"Options: 'start_case', 'lower_case', 'upper_case', 'camel_case', 'snake_case', 'match_source_names'.",
+			ValidateFunc: validation.StringInSlice([]string{
+				"start_case", "lower_case", "upper_case", "camel_case", "snake_case", "match_source_names",
+			}, false),
+		},
+		"field_order": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			Description: "Specify how destination fields should be ordered. Options: 'alphabetical_column_name' (default) or 'mapping_order'. " +
+				"Only works on destinations that support field ordering.",
+			ValidateFunc: validation.StringInSlice([]string{
+				"alphabetical_column_name", "mapping_order",
+			}, false),
+		},
+		"sync_behavior_family": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			Description: "Specifies the behavior family for the sync. Use 'activateEvents' for event-based activation syncs " +
+				"(only supported for live syncs from Kafka/streaming sources). Use 'mapRecords' for record mapping syncs " +
+				"(not supported for live syncs from Materialize).",
+			ValidateFunc: validation.StringInSlice([]string{
+				"activateEvents", "mapRecords",
+			}, false),
+		},
+		"advanced_configuration": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "Advanced configuration options specific to the destination type as JSON. Use jsonencode() to specify values. Available options vary by destination.",
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: suppressEquivalentJSON,
+		},
+		"high_water_mark_attribute": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The name of the timestamp column to use for high water mark diffing strategy. When set, append syncs will use this column to identify new records instead of the default Census diff engine (using primary keys). Example: 'updated_at'.",
+		},
+		"historical_sync_operation": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			Description: "Specifies how the first sync should handle historical records when using append operation. " +
+				"Only applicable for append syncs. Options: 'skip_current_records' (skip existing records on first sync) or " +
+				"'backfill_all_records' (include all existing records on first sync).",
+			ValidateFunc: validation.StringInSlice([]string{
+				"skip_current_records", "backfill_all_records",
+			}, false),
+		},
+		"mirror_strategy": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			Description: "Specifies the strategy for mirror syncs. Only applicable when operation is 'mirror'. " +
+				"Options: 'sync_updates_and_deletes' (incrementally sync changes - most common), " +
+				"'sync_updates_and_nulls' (update records and set nulls without deletes), " +
+				"'upload_and_swap' (replace entire destination table with source snapshot).",
+			ValidateFunc: validation.StringInSlice([]string{
+				"sync_updates_and_deletes",
+				"sync_updates_and_nulls",
+				"upload_and_swap",
+			}, false),
+		},
+		"alert": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Alert configurations for the sync. Multiple alerts of different types can be configured.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The ID of the alert configuration (assigned by Census).",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Type of alert configuration.",
+						ValidateFunc: validation.StringInSlice([]string{
+							"FailureAlertConfiguration",
+							"InvalidRecordPercentAlertConfiguration",
+							"FullSyncTriggerAlertConfiguration",
+							"RecordCountDeviationAlertConfiguration",
+							"RuntimeAlertConfiguration",
+							"StatusAlertConfiguration",
+						}, false),
+					},
+					"send_for": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "first_time",
+						Description: "When to send alerts: 'first_time' (default) or 'every_time'.",
+						ValidateFunc: validation.StringInSlice([]string{
+							"first_time",
+							"every_time",
+						}, false),
+					},
+					"should_send_recovery": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+						Description: "Whether to send a recovery notification when the alert condition is resolved.",
+					},
+					"options": {
+						Type:        schema.TypeMap,
+						Optional:    true,
+						Description: "Alert-specific options (e.g., threshold for InvalidRecordPercentAlertConfiguration).",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
 						},
 					},
 				},
-				Set: alertHash,
 			},
-			"run_mode": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				Description: "Run mode configuration for the sync (live vs triggered with various trigger types).",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"type": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Mode type: 'live' for continuous syncing or 'triggered' for event-based syncing.",
-							ValidateFunc: validation.StringInSlice([]string{
-								"live", "triggered",
-							}, false),
-						},
-						"triggers": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							MaxItems:    1,
-							Description: "Trigger configurations (only for 'triggered' mode).",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"schedule": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										Description: "Schedule-based trigger configuration.",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"frequency": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "Sync frequency: never, continuous, quarter_hourly, hourly, daily, weekly, or expression (for cron).",
-													ValidateFunc: validation.StringInSlice([]string{
-														"never", "continuous", "quarter_hourly", "hourly", "daily", "weekly", "expression",
-													}, false),
-												},
-												"day": {
-													Type:        schema.TypeString,
-													Optional:    true,
-													Description: "Day of week (Sunday-Saturday, for weekly schedules).",
-													ValidateFunc: validation.StringInSlice([]string{
-														"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
-													}, false),
-												},
-												"hour": {
-													Type:         schema.TypeInt,
-													Optional:     true,
-													Description:  "Hour to run (0-24).",
-													ValidateFunc: validation.IntBetween(0, 24),
-												},
-												"minute": {
-													Type:         schema.TypeInt,
-													Optional:     true,
-													Description:  "Minute to run (0-59).",
-													ValidateFunc: validation.IntBetween(0, 59),
-												},
-												"cron_expression": {
-													Type:        schema.TypeString,
-													Optional:    true,
-													Description: "Cron expression (only valid when frequency is 'expression').",
-												},
+			Set: alertHash,
+		},
+		"run_mode": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Run mode configuration for the sync (live vs triggered with various trigger types).",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Mode type: 'live' for continuous syncing or 'triggered' for event-based syncing.",
+						ValidateFunc: validation.StringInSlice([]string{
+							"live", "triggered",
+						}, false),
+					},
+					"triggers": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Trigger configurations (only for 'triggered' mode).",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"schedule": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "Schedule-based trigger configuration.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"frequency": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "Sync frequency: never, continuous, quarter_hourly, hourly, daily, weekly, or expression (for cron).",
+												ValidateFunc: validation.StringInSlice([]string{
+													"never", "continuous", "quarter_hourly", "hourly", "daily", "weekly", "expression",
+												}, false),
+											},
+											"day": {
+												Type:        schema.TypeString,
+												Optional:    true,
+												Description: "Day of week (Sunday-Saturday, for weekly schedules).",
+												ValidateFunc: validation.StringInSlice([]string{
+													"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+												}, false),
+											},
+											"hour": {
+												Type:         schema.TypeInt,
+												Optional:     true,
+												Description:  "Hour to run (0-24).",
+												ValidateFunc: validation.IntBetween(0, 24),
+											},
+											"minute": {
+												Type:         schema.TypeInt,
+												Optional:     true,
+												Description:  "Minute to run (0-59).",
+												ValidateFunc: validation.IntBetween(0, 59),
+											},
+											"cron_expression": {
+												Type:             schema.TypeString,
+												Optional:         true,
+												Description:      "Cron expression (only valid when frequency is 'expression').",
+												ValidateDiagFunc: validateCronExpression,
 											},
 										},
 									},
-									"dbt_cloud": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										Description: "dbt Cloud job trigger configuration.",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"project_id": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "dbt Cloud project ID.",
-												},
-												"job_id": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "dbt Cloud job ID.",
-												},
+								},
+								"dbt_cloud": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "dbt Cloud job trigger configuration.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"project_id": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "dbt Cloud project ID.",
+											},
+											"job_id": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "dbt Cloud job ID.",
 											},
 										},
 									},
-									"fivetran": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										Description: "Fivetran connector trigger configuration.",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"job_id": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "Fivetran job ID.",
-												},
-												"job_name": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "Fivetran job name.",
-												},
+								},
+								"fivetran": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "Fivetran connector trigger configuration.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"job_id": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "Fivetran job ID.",
+											},
+											"job_name": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "Fivetran job name.",
 											},
 										},
 									},
-									"sync_sequence": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										Description: "Sync dependency trigger configuration (triggers after another sync completes).",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"sync_id": {
-													Type:        schema.TypeInt,
-													Required:    true,
-													Description: "ID of the sync to trigger after.",
-												},
+								},
+								"sync_sequence": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "Sync dependency trigger configuration (triggers after another sync completes). Cycles are rejected at plan time only when they pass through syncs as they exist in Census; a cycle formed only by syncs created or changed in the same plan is not detected.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"sync_id": {
+												Type:        schema.TypeInt,
+												Required:    true,
+												Description: "ID of the sync to trigger after.",
 											},
 										},
 									},
@@ -506,38 +534,46 @@ func resourceSync() *schema.Resource {
 					},
 				},
 			},
-			// Computed fields
-			"status": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Current status of the sync.",
-			},
-			"created_at": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Timestamp when the sync was created.",
-			},
-			"updated_at": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Timestamp when the sync was last updated.",
-			},
-			"last_run_at": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Timestamp when the sync was last executed.",
-			},
-			"next_run_at": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Timestamp when the sync is scheduled to run next.",
-			},
-			"last_run_id": {
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Description: "ID of the last sync run.",
+		},
+		// Computed fields
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Current status of the sync.",
+		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Timestamp when the sync was created.",
+		},
+		"updated_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Timestamp when the sync was last updated.",
+		},
+		"last_run_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Timestamp when the sync was last executed.",
+		},
+		"next_run_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Timestamp when the sync is scheduled to run next.",
+		},
+		"next_runs": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Preview of the next 5 times the run_mode schedule fires, as UTC RFC 3339 timestamps. Empty for schedules without fixed times and for syncs that are not triggered by a schedule.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
 		},
+		"last_run_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "ID of the last sync run.",
+		},
 	}
 }
 
@@ -598,23 +634,15 @@ func resourceSyncCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	apiClient := meta.(*client.Client)
 
 	workspaceId := d.Get("workspace_id").(string)
+	ctx = syncLogContext(ctx, workspaceId, "")
 
-	// Get the workspace API key dynamically using the personal access token
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
-	}
-
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.Errorf("failed to get workspace API key for workspace %d: %v", workspaceIdInt, err)
-	}
-	if workspaceToken == "" {
-		return diag.Errorf("workspace API key is empty for workspace %d", workspaceIdInt)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
 	destinationAttributes := ExpandDestinationAttributes(d.Get("destination_attributes").([]interface{}))
-	fieldMappings := ExpandFieldMappings(d.Get("field_mapping").([]interface{}))
+	fieldMappings := ExpandFieldMappings(d.Get("field_mapping").(*schema.Set).List())
 
 	// Validate exactly one primary identifier
 	if err := validatePrimaryIdentifier(fieldMappings); err != nil {
@@ -674,15 +702,18 @@ func resourceSyncCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		AlertAttributes: ExpandAlerts(d.Get("alert").(*schema.Set).List()),
 	}
 
-	fmt.Printf("[DEBUG] Creating sync with request: %+v\n", req)
+	tflog.SubsystemDebug(ctx, syncLogSubsystem, "Creating sync", map[string]interface{}{
+		"field_mapping_count": len(req.Mappings),
+	})
 	sync, err := apiClient.CreateSyncWithToken(ctx, req, workspaceToken)
 	if err != nil {
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceSync().Schema, syncAPIFields)
 	}
 
-	fmt.Printf("[DEBUG] Sync created successfully with ID: %d\n", sync.ID)
 	d.SetId(strconv.Itoa(sync.ID))
-	fmt.Printf("[DEBUG] Resource ID set to: %s\n", d.Id())
+	tflog.SubsystemInfo(ctx, syncLogSubsystem, "Created sync", map[string]interface{}{
+		"sync_id": sync.ID,
+	})
 
 	// Explicitly set workspace_id from our input since API doesn't return it
 	d.Set("workspace_id", workspaceId)
@@ -698,30 +729,19 @@ func resourceSyncRead(ctx context.Context, d *schema.ResourceData, meta interfac
 		return diag.Errorf("invalid sync ID: %s", d.Id())
 	}
 
-	// DEBUG: Log entry point
-	fmt.Printf("[DEBUG] Starting resourceSyncRead for sync ID: %d\n", id)
-
 	// Get workspace token dynamically if we have workspace_id
 	workspaceId := d.Get("workspace_id").(string)
-	fmt.Printf("[DEBUG] Got workspace_id from state: %s\n", workspaceId)
+	ctx = syncLogContext(ctx, workspaceId, d.Id())
+	tflog.SubsystemDebug(ctx, syncLogSubsystem, "Reading sync")
 
 	var sync *client.Sync
 	if workspaceId != "" {
-		workspaceIdInt, err := strconv.Atoi(workspaceId)
-		if err != nil {
-			return diag.Errorf("invalid workspace ID: %s", workspaceId)
-		}
-
-		fmt.Printf("[DEBUG] Getting workspace token for workspace %d\n", workspaceIdInt)
-		workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-		if err != nil {
-			fmt.Printf("[DEBUG] Failed to get workspace API key: %v\n", err)
-			return diag.FromErr(err)
+		workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+		if diags.HasError() {
+			return diags
 		}
 
-		fmt.Printf("[DEBUG] Successfully got workspace token, calling GetSyncWithToken for sync %d\n", id)
 		sync, err = apiClient.GetSyncWithToken(ctx, id, workspaceToken)
-		fmt.Printf("[DEBUG] GetSyncWithToken completed - sync: %v, err: %v\n", sync != nil, err)
 	} else {
 		return diag.Errorf(`workspace_id is required but missing from resource state.
 
@@ -731,10 +751,9 @@ To fix this, add the missing workspace_id to terraform state:
 	}
 
 	if err != nil {
-		fmt.Printf("[DEBUG] Error from GetSyncWithToken: %v\n", err)
 		// Check if sync was not found
 		if IsNotFoundError(err) {
-			fmt.Printf("[DEBUG] Sync not found, clearing resource ID\n")
+			tflog.SubsystemWarn(ctx, syncLogSubsystem, "Sync not found, removing it from state")
 			d.SetId("")
 			return nil
 		}
@@ -743,13 +762,11 @@ To fix this, add the missing workspace_id to terraform state:
 
 	// Check if sync is nil (API returned successfully but with nil data)
 	if sync == nil {
-		fmt.Printf("[DEBUG] Sync is nil, clearing resource ID\n")
+		tflog.SubsystemWarn(ctx, syncLogSubsystem, "Sync API response contained no data, removing it from state")
 		d.SetId("")
 		return nil
 	}
 
-	fmt.Printf("[DEBUG] Sync found successfully, setting resource attributes\n")
-
 	// Only update workspace_id if API returned it, otherwise preserve what's in state
 	if sync.WorkspaceID != "" {
 		d.Set("workspace_id", sync.WorkspaceID)
@@ -782,7 +799,6 @@ To fix this, add the missing workspace_id to terraform state:
 	// Set advanced configuration if present
 	if sync.AdvancedConfiguration != nil && len(sync.AdvancedConfiguration) > 0 {
 		if err := d.Set("advanced_configuration", FlattenAdvancedConfiguration(sync.AdvancedConfiguration)); err != nil {
-			fmt.Printf("[DEBUG] Failed to set advanced_configuration: %v\n", err)
 			return diag.Errorf("failed to set advanced_configuration: %v", err)
 		}
 	}
@@ -805,7 +821,6 @@ To fix this, add the missing workspace_id to terraform state:
 	// Set alert attributes if present
 	if len(sync.AlertAttributes) > 0 {
 		if err := d.Set("alert", FlattenAlerts(sync.AlertAttributes)); err != nil {
-			fmt.Printf("[DEBUG] Failed to set alert: %v\n", err)
 			return diag.Errorf("failed to set alert: %v", err)
 		}
 	}
@@ -828,270 +843,155 @@ To fix this, add the missing workspace_id to terraform state:
 		d.Set("last_run_id", *sync.LastRunID)
 	}
 
-	// Handle run_mode from API response
-	if sync.Mode != nil {
-		fmt.Printf("[DEBUG] Setting run_mode from API response\n")
-		if err := d.Set("run_mode", FlattenRunMode(sync.Mode)); err != nil {
-			fmt.Printf("[DEBUG] Failed to set run_mode: %v\n", err)
+	// Syncs created before the API supported modes report flat schedule fields instead, which are
+	// read as the equivalent run_mode so that configuring it does not plan a change
+	mode := LegacySyncMode(sync)
+	if mode != nil && sync.Mode == nil {
+		tflog.SubsystemDebug(ctx, syncLogSubsystem, "Legacy sync schedule read as run_mode", map[string]interface{}{
+			"schedule_frequency": sync.ScheduleFrequency,
+		})
+	}
+
+	if err := d.Set("next_runs", NextScheduledRuns(syncSchedule(mode), time.Now(), nextRunsPreviewCount)); err != nil {
+		return diag.Errorf("failed to set next_runs: %v", err)
+	}
+
+	if mode != nil {
+		if err := d.Set("run_mode", FlattenRunMode(mode)); err != nil {
 			return diag.Errorf("failed to set run_mode: %v", err)
 		}
-	} else if sync.ScheduleFrequency != "" {
-		// Handle very old syncs that pre-date Mode API field (created before Census added Mode support)
-		fmt.Printf("[DEBUG] Legacy sync detected - has flat schedule fields but no Mode\n")
-		return diag.Errorf("This sync was created with an older version of the Census API that pre-dates run_mode support. Please recreate it using run_mode configuration or contact Census support to migrate it.")
 	}
 
 	// Set complex attributes with nil checks
-	fmt.Printf("[DEBUG] Setting source_attributes\n")
 	if err := d.Set("source_attributes", FlattenSourceAttributes(sync.SourceAttributes)); err != nil {
-		fmt.Printf("[DEBUG] Failed to set source_attributes: %v\n", err)
 		return diag.Errorf("failed to set source_attributes: %v", err)
 	}
 
-	fmt.Printf("[DEBUG] Setting destination_attributes\n")
 	if err := d.Set("destination_attributes", FlattenDestinationAttributes(sync.DestinationAttributes)); err != nil {
-		fmt.Printf("[DEBUG] Failed to set destination_attributes: %v\n", err)
 		return diag.Errorf("failed to set destination_attributes: %v", err)
 	}
 
 	// Convert API Mappings back to Terraform FieldMappings with defensive handling
-	fmt.Printf("[DEBUG] Converting field mappings - Mappings: %v, FieldMappings: %v\n", sync.Mappings != nil, sync.FieldMappings != nil)
 	var fieldMappings []client.FieldMapping
 	if sync.Mappings != nil && len(sync.Mappings) > 0 {
-		fmt.Printf("[DEBUG] Using sync.Mappings (count: %d)\n", len(sync.Mappings))
 		fieldMappings = convertMappingAttributesToFieldMappings(sync.Mappings)
 	} else if sync.FieldMappings != nil {
-		fmt.Printf("[DEBUG] Using sync.FieldMappings (count: %d)\n", len(sync.FieldMappings))
+		tflog.SubsystemDebug(ctx, syncLogSubsystem, "Sync response has no mappings, using legacy field_mappings")
 		fieldMappings = sync.FieldMappings // Fallback to legacy field
 	} else {
-		fmt.Printf("[DEBUG] Using empty field mappings\n")
 		fieldMappings = []client.FieldMapping{} // Empty slice as fallback
 	}
 
-	fmt.Printf("[DEBUG] Setting field_mapping\n")
 	if err := d.Set("field_mapping", FlattenFieldMappings(fieldMappings)); err != nil {
-		fmt.Printf("[DEBUG] Failed to set field_mapping: %v\n", err)
 		return diag.Errorf("failed to set field_mapping: %v", err)
 	}
 
-	// Handle sync_key with nil check
-	fmt.Printf("[DEBUG] Setting sync_key (nil: %v)\n", sync.SyncKey == nil)
-	if sync.SyncKey != nil {
-		if err := d.Set("sync_key", sync.SyncKey); err != nil {
-			fmt.Printf("[DEBUG] Failed to set sync_key: %v\n", err)
-			return diag.Errorf("failed to set sync_key: %v", err)
-		}
-	}
-
-	// Schedule is already set above from flat API response fields
-
-	fmt.Printf("[DEBUG] resourceSyncRead completed successfully\n")
-
 	return nil
 }
 
 func resourceSyncUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fmt.Printf("[DEBUG] === Starting resourceSyncUpdate ===\n")
-
 	apiClient := meta.(*client.Client)
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
-		fmt.Printf("[DEBUG] Error parsing sync ID: %v\n", err)
 		return diag.Errorf("invalid sync ID: %s", d.Id())
 	}
-	fmt.Printf("[DEBUG] Updating sync with ID: %d\n", id)
 
-	// Safe type assertion for workspace_id
-	workspaceIdInterface := d.Get("workspace_id")
-	workspaceId, ok := workspaceIdInterface.(string)
-	if !ok {
-		fmt.Printf("[DEBUG] workspace_id is not a string, type: %T, value: %+v\n", workspaceIdInterface, workspaceIdInterface)
-		return diag.Errorf("workspace_id is not a valid string: %v", workspaceIdInterface)
+	workspaceId := d.Get("workspace_id").(string)
+	ctx = syncLogContext(ctx, workspaceId, d.Id())
+
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
+	req, err := expandUpdateSyncRequest(d)
 	if err != nil {
-		fmt.Printf("[DEBUG] Error parsing workspace ID: %v\n", err)
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
+		return diag.FromErr(err)
 	}
 
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
+	tflog.SubsystemDebug(ctx, syncLogSubsystem, "Updating sync", map[string]interface{}{
+		"field_mapping_count": len(req.Mappings),
+	})
+	_, err = apiClient.UpdateSyncWithToken(ctx, id, req, workspaceToken)
 	if err != nil {
-		fmt.Printf("[DEBUG] Error getting workspace token: %v\n", err)
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceSync().Schema, syncAPIFields)
 	}
 
-	fmt.Printf("[DEBUG] Building update request...\n")
+	return resourceSyncRead(ctx, d, meta)
+}
 
-	// Handle run_mode
-	var mode *client.SyncMode
-	runModeRaw := d.Get("run_mode").([]interface{})
+// expandUpdateSyncRequest builds a PATCH request carrying only the attributes that changed, so that
+// settings managed outside Terraform are left alone. Mappings use the same format as on create.
+func expandUpdateSyncRequest(d *schema.ResourceData) (*client.UpdateSyncRequest, error) {
+	req := &client.UpdateSyncRequest{}
 
-	if len(runModeRaw) > 0 {
-		mode = ExpandRunMode(runModeRaw)
-		fmt.Printf("[DEBUG] Using run_mode: %+v\n", mode)
+	if d.HasChange("label") {
+		req.Label = d.Get("label").(string)
 	}
-
-	// Safe type assertions for all fields
-	labelInterface := d.Get("label")
-	label, ok := labelInterface.(string)
-	if !ok {
-		fmt.Printf("[DEBUG] label is not a string, type: %T, value: %+v\n", labelInterface, labelInterface)
-		return diag.Errorf("label is not a valid string: %v", labelInterface)
+	if d.HasChange("operation") {
+		req.Operation = d.Get("operation").(string)
 	}
-
-	sourceAttrsInterface := d.Get("source_attributes")
-	var sourceAttrs map[string]interface{}
-
-	// Handle both map and list formats
-	switch v := sourceAttrsInterface.(type) {
-	case map[string]interface{}:
-		sourceAttrs = v
-	case []interface{}:
-		if len(v) > 0 {
-			if m, ok := v[0].(map[string]interface{}); ok {
-				sourceAttrs = m
-			} else {
-				fmt.Printf("[DEBUG] source_attributes list element is not a map, type: %T, value: %+v\n", v[0], v[0])
-				return diag.Errorf("source_attributes list element is not a valid map: %v", v[0])
-			}
-		} else {
-			sourceAttrs = make(map[string]interface{})
-		}
-	default:
-		fmt.Printf("[DEBUG] source_attributes is not a map or list, type: %T, value: %+v\n", sourceAttrsInterface, sourceAttrsInterface)
-		return diag.Errorf("source_attributes is not a valid map or list: %v", sourceAttrsInterface)
-	}
-
-	// Additional processing to extract object field from list format
-	if sourceAttrs != nil {
-		if objData, exists := sourceAttrs["object"]; exists {
-			switch v := objData.(type) {
-			case []interface{}:
-				// Object stored as list in Terraform state - extract first element
-				if len(v) > 0 {
-					if obj, ok := v[0].(map[string]interface{}); ok {
-						fmt.Printf("[DEBUG] Extracted object from list in UPDATE: %+v\n", obj)
-						sourceAttrs["object"] = obj
-					} else {
-						fmt.Printf("[DEBUG] object list element is not a map, type: %T, value: %+v\n", v[0], v[0])
-					}
-				}
-			case map[string]interface{}:
-				// Object is already a direct map - no change needed
-				fmt.Printf("[DEBUG] object is already a map in UPDATE: %+v\n", v)
-			default:
-				fmt.Printf("[DEBUG] object is unexpected type in UPDATE: %T, value: %+v\n", v, v)
-			}
-		}
+	if d.HasChange("source_attributes") {
+		req.SourceAttributes = ExpandSourceAttributes(d.Get("source_attributes").([]interface{}))
 	}
-
-	destAttrsInterface := d.Get("destination_attributes")
-	var destAttrs map[string]interface{}
-
-	// Handle both map and list formats
-	switch v := destAttrsInterface.(type) {
-	case map[string]interface{}:
-		destAttrs = v
-	case []interface{}:
-		if len(v) > 0 {
-			if m, ok := v[0].(map[string]interface{}); ok {
-				destAttrs = m
-			} else {
-				fmt.Printf("[DEBUG] destination_attributes list element is not a map, type: %T, value: %+v\n", v[0], v[0])
-				return diag.Errorf("destination_attributes list element is not a valid map: %v", v[0])
-			}
-		} else {
-			destAttrs = make(map[string]interface{})
+	if d.HasChange("destination_attributes") {
+		req.DestinationAttributes = ExpandDestinationAttributes(d.Get("destination_attributes").([]interface{}))
+	}
+	if d.HasChange("field_mapping") {
+		fieldMappings := ExpandFieldMappings(d.Get("field_mapping").(*schema.Set).List())
+		if err := validatePrimaryIdentifier(fieldMappings); err != nil {
+			return nil, err
 		}
-	default:
-		fmt.Printf("[DEBUG] destination_attributes is not a map or list, type: %T, value: %+v\n", destAttrsInterface, destAttrsInterface)
-		return diag.Errorf("destination_attributes is not a valid map or list: %v", destAttrsInterface)
+		req.Mappings = convertFieldMappingsToMappingAttributes(fieldMappings)
 	}
-
-	fieldMappingsInterface := d.Get("field_mapping")
-	fieldMappings, ok := fieldMappingsInterface.([]interface{})
-	if !ok {
-		fmt.Printf("[DEBUG] field_mapping is not a []interface{}, type: %T, value: %+v\n", fieldMappingsInterface, fieldMappingsInterface)
-		return diag.Errorf("field_mapping is not a valid list: %v", fieldMappingsInterface)
+	if d.HasChange("run_mode") {
+		req.Mode = ExpandRunMode(d.Get("run_mode").([]interface{}))
 	}
-
-	pausedInterface := d.Get("paused")
-	paused, ok := pausedInterface.(bool)
-	if !ok {
-		fmt.Printf("[DEBUG] paused is not a bool, type: %T, value: %+v\n", pausedInterface, pausedInterface)
-		return diag.Errorf("paused is not a valid boolean: %v", pausedInterface)
+	if d.HasChange("paused") {
+		paused := d.Get("paused").(bool)
+		req.Paused = &paused
 	}
-
-	// Safe type assertions for field configuration
-	fieldBehaviorInterface := d.Get("field_behavior")
-	fieldBehavior, _ := fieldBehaviorInterface.(string)
-
-	fieldNormalizationInterface := d.Get("field_normalization")
-	fieldNormalization, _ := fieldNormalizationInterface.(string)
-
-	fieldOrderInterface := d.Get("field_order")
-	fieldOrder, _ := fieldOrderInterface.(string)
-
-	syncBehaviorFamilyInterface := d.Get("sync_behavior_family")
-	syncBehaviorFamily, _ := syncBehaviorFamilyInterface.(string)
-
-	// Safe type assertions for alerts
-	alertInterface := d.Get("alert")
-	alertSet, ok := alertInterface.(*schema.Set)
-	if !ok {
-		fmt.Printf("[DEBUG] alert is not a *schema.Set, type: %T, value: %+v\n", alertInterface, alertInterface)
-		return diag.Errorf("alert is not a valid set: %v", alertInterface)
+	if d.HasChange("field_behavior") {
+		req.FieldBehavior = d.Get("field_behavior").(string)
 	}
-
-	req := &client.UpdateSyncRequest{
-		Label:                 label,
-		SourceAttributes:      ExpandSourceAttributes(d.Get("source_attributes").([]interface{})),
-		DestinationAttributes: ExpandStringMap(destAttrs),
-		FieldMappings:         ExpandFieldMappings(fieldMappings),
-		Paused:                paused,
-
-		// Mode - live vs triggered with trigger configurations
-		Mode: mode,
-
-		// Field configuration
-		FieldBehavior:      fieldBehavior,
-		FieldNormalization: fieldNormalization,
-		FieldOrder:         fieldOrder,
-
-		// Sync behavior family
-		SyncBehaviorFamily: syncBehaviorFamily,
-
-		// Advanced configuration
-		AdvancedConfiguration: ExpandAdvancedConfiguration(d.Get("advanced_configuration").(string)),
-
-		// High water mark attribute
-		HighWaterMarkAttribute: d.Get("high_water_mark_attribute").(string),
-
-		// Historical sync operation
-		HistoricalSyncOperation: d.Get("historical_sync_operation").(string),
-
-		// Mirror strategy
-		MirrorStrategy: d.Get("mirror_strategy").(string),
-
-		// Alert configuration
-		AlertAttributes: ExpandAlerts(alertSet.List()),
+	if d.HasChange("field_normalization") {
+		req.FieldNormalization = d.Get("field_normalization").(string)
 	}
-
-	fmt.Printf("[DEBUG] Update request: %+v\n", req)
-
-	fmt.Printf("[DEBUG] Calling UpdateSyncWithToken...\n")
-	_, err = apiClient.UpdateSyncWithToken(ctx, id, req, workspaceToken)
-	if err != nil {
-		fmt.Printf("[DEBUG] UpdateSyncWithToken failed: %v\n", err)
-		return diag.FromErr(err)
+	if d.HasChange("field_order") {
+		req.FieldOrder = d.Get("field_order").(string)
+	}
+	if d.HasChange("sync_behavior_family") {
+		req.SyncBehaviorFamily = d.Get("sync_behavior_family").(string)
+	}
+	if d.HasChange("advanced_configuration") {
+		// An empty object clears the configuration; omitting it would leave the old one in place
+		advancedConfiguration := ExpandAdvancedConfiguration(d.Get("advanced_configuration").(string))
+		if advancedConfiguration == nil {
+			advancedConfiguration = map[string]interface{}{}
+		}
+		req.AdvancedConfiguration = &advancedConfiguration
+	}
+	if d.HasChange("high_water_mark_attribute") {
+		highWaterMarkAttribute := d.Get("high_water_mark_attribute").(string)
+		req.HighWaterMarkAttribute = &highWaterMarkAttribute
+	}
+	if d.HasChange("historical_sync_operation") {
+		req.HistoricalSyncOperation = d.Get("historical_sync_operation").(string)
+	}
+	if d.HasChange("mirror_strategy") {
+		mirrorStrategy := d.Get("mirror_strategy").(string)
+		req.MirrorStrategy = &mirrorStrategy
+	}
+	if d.HasChange("alert") {
+		alerts := ExpandAlerts(d.Get("alert").(*schema.Set).List())
+		if alerts == nil {
+			alerts = []client.AlertAttribute{}
+		}
+		req.AlertAttributes = &alerts
 	}
 
-	fmt.Printf("[DEBUG] UpdateSyncWithToken succeeded, calling resourceSyncRead...\n")
-	result := resourceSyncRead(ctx, d, meta)
-	fmt.Printf("[DEBUG] === resourceSyncUpdate completed ===\n")
-	return result
+	return req, nil
 }
 
 func resourceSyncDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -1103,16 +1003,14 @@ func resourceSyncDelete(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 
 	workspaceId := d.Get("workspace_id").(string)
-	workspaceIdInt, err := strconv.Atoi(workspaceId)
-	if err != nil {
-		return diag.Errorf("invalid workspace ID: %s", workspaceId)
-	}
+	ctx = syncLogContext(ctx, workspaceId, d.Id())
 
-	workspaceToken, err := apiClient.GetWorkspaceAPIKey(ctx, workspaceIdInt)
-	if err != nil {
-		return diag.FromErr(err)
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
 	}
 
+	tflog.SubsystemDebug(ctx, syncLogSubsystem, "Deleting sync")
 	err = apiClient.DeleteSyncWithToken(ctx, id, workspaceToken)
 	if err != nil {
 		return diag.FromErr(err)
@@ -1126,11 +1024,10 @@ func resourceSyncDelete(ctx context.Context, d *schema.ResourceData, meta interf
 
 func ExpandFieldMappings(mappings []interface{}) []client.FieldMapping {
 	result := make([]client.FieldMapping, 0, len(mappings))
-	for i, mapping := range mappings {
+	for _, mapping := range mappings {
 		// Safe type assertion for mapping
 		m, ok := mapping.(map[string]interface{})
 		if !ok {
-			fmt.Printf("[DEBUG] ExpandFieldMappings: mappings[%d] is not a map[string]interface{}, type: %T, value: %+v\n", i, mapping, mapping)
 			continue // Skip invalid entries
 		}
 
@@ -1141,14 +1038,10 @@ func ExpandFieldMappings(mappings []interface{}) []client.FieldMapping {
 		// Safe type assertions for string fields
 		if from, ok := m["from"].(string); ok {
 			fieldMapping.From = from
-		} else {
-			fmt.Printf("[DEBUG] ExpandFieldMappings: mappings[%d]['from'] is not a string, type: %T, value: %+v\n", i, m["from"], m["from"])
 		}
 
 		if to, ok := m["to"].(string); ok {
 			fieldMapping.To = to
-		} else {
-			fmt.Printf("[DEBUG] ExpandFieldMappings: mappings[%d]['to'] is not a string, type: %T, value: %+v\n", i, m["to"], m["to"])
 		}
 
 		// Get type field (defaults to "direct" in schema)
@@ -1171,14 +1064,6 @@ func ExpandFieldMappings(mappings []interface{}) []client.FieldMapping {
 			fieldMapping.LiquidTemplate = liquidTemplate
 		}
 
-		// Validate: if constant is present, type must be "constant"
-		if fieldMapping.Constant != nil && fieldMapping.Constant != "" {
-			if mappingType != "constant" {
-				fmt.Printf("[ERROR] ExpandFieldMappings: field_mapping[%d] has a constant value but type is '%s'. When using constant, type must be 'constant'.\n", i, mappingType)
-				// Continue processing but log the error - validation should catch this
-			}
-		}
-
 		if isPrimary, ok := m["is_primary_identifier"].(bool); ok {
 			fieldMapping.IsPrimaryIdentifier = isPrimary
 		}
@@ -1262,10 +1147,9 @@ func ExpandAlerts(alerts []interface{}) []client.AlertAttribute {
 	}
 
 	result := make([]client.AlertAttribute, 0, len(alerts))
-	for i, alert := range alerts {
+	for _, alert := range alerts {
 		m, ok := alert.(map[string]interface{})
 		if !ok {
-			fmt.Printf("[DEBUG] ExpandAlerts: alerts[%d] is not a map[string]interface{}, type: %T, value: %+v\n", i, alert, alert)
 			continue
 		}
 
@@ -1344,10 +1228,7 @@ func FlattenAlerts(alerts []client.AlertAttribute) []interface{} {
 }
 
 func ExpandSyncSchedule(schedules []interface{}) *client.SyncSchedule {
-	fmt.Printf("[DEBUG] ExpandSyncSchedule called with: %+v\n", schedules)
-
 	if len(schedules) == 0 || schedules[0] == nil {
-		fmt.Printf("[DEBUG] ExpandSyncSchedule returning nil (empty or nil schedule)\n")
 		return nil
 	}
 
@@ -1355,18 +1236,14 @@ func ExpandSyncSchedule(schedules []interface{}) *client.SyncSchedule {
 	sInterface := schedules[0]
 	s, ok := sInterface.(map[string]interface{})
 	if !ok {
-		fmt.Printf("[DEBUG] ExpandSyncSchedule: schedules[0] is not a map[string]interface{}, type: %T, value: %+v\n", sInterface, sInterface)
 		return nil
 	}
-	fmt.Printf("[DEBUG] schedule map: %+v\n", s)
 
 	// Safely extract values with defaults
 	frequency := ""
 	if freq, ok := s["frequency"]; ok && freq != nil {
 		if freqStr, ok := freq.(string); ok {
 			frequency = freqStr
-		} else {
-			fmt.Printf("[DEBUG] ExpandSyncSchedule: frequency is not a string, type: %T, value: %+v\n", freq, freq)
 		}
 	}
 
@@ -1374,8 +1251,6 @@ func ExpandSyncSchedule(schedules []interface{}) *client.SyncSchedule {
 	if m, ok := s["minute"]; ok && m != nil {
 		if minuteInt, ok := m.(int); ok {
 			minute = minuteInt
-		} else {
-			fmt.Printf("[DEBUG] ExpandSyncSchedule: minute is not an int, type: %T, value: %+v\n", m, m)
 		}
 	}
 
@@ -1383,8 +1258,6 @@ func ExpandSyncSchedule(schedules []interface{}) *client.SyncSchedule {
 	if h, ok := s["hour"]; ok && h != nil {
 		if hourInt, ok := h.(int); ok {
 			hour = hourInt
-		} else {
-			fmt.Printf("[DEBUG] ExpandSyncSchedule: hour is not an int, type: %T, value: %+v\n", h, h)
 		}
 	}
 
@@ -1392,8 +1265,6 @@ func ExpandSyncSchedule(schedules []interface{}) *client.SyncSchedule {
 	if dow, ok := s["day_of_week"]; ok && dow != nil {
 		if dowInt, ok := dow.(int); ok {
 			dayOfWeek = dowInt
-		} else {
-			fmt.Printf("[DEBUG] ExpandSyncSchedule: day_of_week is not an int, type: %T, value: %+v\n", dow, dow)
 		}
 	}
 
@@ -1401,8 +1272,6 @@ func ExpandSyncSchedule(schedules []interface{}) *client.SyncSchedule {
 	if tz, ok := s["timezone"]; ok && tz != nil {
 		if tzStr, ok := tz.(string); ok {
 			timezone = tzStr
-		} else {
-			fmt.Printf("[DEBUG] ExpandSyncSchedule: timezone is not a string, type: %T, value: %+v\n", tz, tz)
 		}
 	}
 
@@ -1414,22 +1283,17 @@ func ExpandSyncSchedule(schedules []interface{}) *client.SyncSchedule {
 		Timezone:  timezone,
 	}
 
-	fmt.Printf("[DEBUG] ExpandSyncSchedule returning: %+v\n", result)
 	return result
 }
 
 // ExpandRunMode converts Terraform run_mode config to API SyncMode struct
 func ExpandRunMode(runModes []interface{}) *client.SyncMode {
-	fmt.Printf("[DEBUG] ExpandRunMode called with: %+v\n", runModes)
-
 	if len(runModes) == 0 || runModes[0] == nil {
-		fmt.Printf("[DEBUG] ExpandRunMode returning nil (empty or nil run_mode)\n")
 		return nil
 	}
 
 	runModeMap, ok := runModes[0].(map[string]interface{})
 	if !ok {
-		fmt.Printf("[DEBUG] ExpandRunMode: runModes[0] is not a map[string]interface{}, type: %T\n", runModes[0])
 		return nil
 	}
 
@@ -1519,7 +1383,6 @@ func ExpandRunMode(runModes []interface{}) *client.SyncMode {
 		}
 	}
 
-	fmt.Printf("[DEBUG] ExpandRunMode returning: %+v\n", mode)
 	return mode
 }
 
@@ -1658,7 +1521,6 @@ func ExpandAdvancedConfiguration(jsonStr string) map[string]interface{} {
 	}
 	var result map[string]interface{}
 	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
-		fmt.Printf("[DEBUG] Failed to unmarshal advanced_configuration: %v\n", err)
 		return nil
 	}
 	return result
@@ -1670,7 +1532,6 @@ func FlattenAdvancedConfiguration(m map[string]interface{}) string {
 	}
 	jsonBytes, err := json.Marshal(m)
 	if err != nil {
-		fmt.Printf("[DEBUG] Failed to marshal advanced_configuration: %v\n", err)
 		return ""
 	}
 	return string(jsonBytes)
@@ -1796,12 +1657,11 @@ func FlattenSourceAttributes(attrs map[string]interface{}) []map[string]interfac
 
 func ExpandStringList(list []interface{}) []string {
 	result := make([]string, 0, len(list))
-	for i, v := range list {
+	for _, v := range list {
 		// Safe type assertion
 		if str, ok := v.(string); ok {
 			result = append(result, str)
 		} else {
-			fmt.Printf("[DEBUG] ExpandStringList: list[%d] is not a string, type: %T, value: %+v\n", i, v, v)
 			// Skip non-string values instead of panicking
 		}
 	}
@@ -1908,6 +1768,12 @@ func convertFieldMappingsToMappingAttributes(fieldMappings []client.FieldMapping
 			}
 		}
 
+		// Hashing is an operation on a column mapping rather than a mapping type of its own
+		var operation string
+		if fm.Type == "hash" {
+			operation = "hash"
+		}
+
 		result[i] = client.MappingAttributes{
 			From:                mappingFrom,
 			To:                  fm.To,
@@ -1917,6 +1783,10 @@ func convertFieldMappingsToMappingAttributes(fieldMappings []client.FieldMapping
 			PreserveValues:      fm.PreserveValues,
 			GenerateField:       fm.GenerateField,
 			SyncNullValues:      fm.SyncNullValues,
+			ArrayField:          fm.ArrayField,
+			FieldType:           fm.FieldType,
+			FollowSourceType:    fm.FollowSourceType,
+			Operation:           operation,
 		}
 	}
 
@@ -1993,6 +1863,9 @@ func convertMappingAttributesToFieldMappings(mappings []client.MappingAttributes
 
 			default: // "column"
 				mappingType = "direct"
+				if ma.Operation == "hash" {
+					mappingType = "hash"
+				}
 				if dataStr, ok := ma.From.Data.(string); ok {
 					from = dataStr
 				} else {
@@ -2015,6 +1888,9 @@ func convertMappingAttributesToFieldMappings(mappings []client.MappingAttributes
 			PreserveValues:      ma.PreserveValues,
 			GenerateField:       ma.GenerateField,
 			SyncNullValues:      ma.SyncNullValues,
+			ArrayField:          ma.ArrayField,
+			FieldType:           ma.FieldType,
+			FollowSourceType:    ma.FollowSourceType,
 		}
 	}
 
@@ -2031,7 +1907,6 @@ func ExpandSourceAttributes(sourceAttrs []interface{}) map[string]interface{} {
 	attrInterface := sourceAttrs[0]
 	attr, ok := attrInterface.(map[string]interface{})
 	if !ok {
-		fmt.Printf("[DEBUG] ExpandSourceAttributes: sourceAttrs[0] is not a map[string]interface{}, type: %T, value: %+v\n", attrInterface, attrInterface)
 		return nil
 	}
 
@@ -2055,19 +1930,14 @@ func ExpandSourceAttributes(sourceAttrs []interface{}) map[string]interface{} {
 			// Object stored as list in Terraform state
 			if len(v) > 0 {
 				if obj, ok := v[0].(map[string]interface{}); ok {
-					fmt.Printf("[DEBUG] ExpandSourceAttributes: object extracted from list: %+v\n", obj)
 					objectMap = obj
 				} else {
-					fmt.Printf("[DEBUG] ExpandSourceAttributes: objList[0] is not a map[string]interface{}, type: %T, value: %+v\n", v[0], v[0])
 					return result // Return partial result instead of nil
 				}
 			}
 		case map[string]interface{}:
 			// Object is directly a map (direct config)
-			fmt.Printf("[DEBUG] ExpandSourceAttributes: object is direct map: %+v\n", v)
 			objectMap = v
-		default:
-			fmt.Printf("[DEBUG] ExpandSourceAttributes: object is unexpected type: %T, value: %+v\n", objData, objData)
 		}
 	}
 
@@ -2091,7 +1961,6 @@ func ExpandSourceAttributes(sourceAttrs []interface{}) map[string]interface{} {
 			if segmentId, ok := objectMap["id"]; ok && segmentId != "" {
 				result["filter_segment_id"] = segmentId
 			}
-			fmt.Printf("[DEBUG] ExpandSourceAttributes: Translated segment source - object: %+v, filter_segment_id: %+v\n", translatedObject, result["filter_segment_id"])
 
 		case "cohort":
 			// User provides: type="cohort", id=<cohort_id>, dataset_id=<dataset_id>
@@ -2105,13 +1974,11 @@ func ExpandSourceAttributes(sourceAttrs []interface{}) map[string]interface{} {
 			if cohortId, ok := objectMap["id"]; ok && cohortId != "" {
 				result["cohort_id"] = cohortId
 			}
-			fmt.Printf("[DEBUG] ExpandSourceAttributes: Translated cohort source - object: %+v, cohort_id: %+v\n", translatedObject, result["cohort_id"])
 
 		default:
 			// For all other types (model, topic, dataset, table), pass through as-is
 			// But clean empty strings to avoid API errors
 			result["object"] = CleanEmptyStrings(objectMap)
-			fmt.Printf("[DEBUG] ExpandSourceAttributes: Pass-through object for type %s (cleaned): %+v\n", objectType, result["object"])
 		}
 	}
 
@@ -2133,7 +2000,6 @@ func ExpandDestinationAttributes(destAttrs []interface{}) map[string]interface{}
 	attrInterface := destAttrs[0]
 	attr, ok := attrInterface.(map[string]interface{})
 	if !ok {
-		fmt.Printf("[DEBUG] ExpandDestinationAttributes: destAttrs[0] is not a map[string]interface{}, type: %T, value: %+v\n", attrInterface, attrInterface)
 		return nil
 	}
 
@@ -2188,27 +2054,28 @@ func FlattenDestinationAttributes(attrs map[string]interface{}) []map[string]int
 }
 
 func resourceSyncImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	// Support composite format: workspace_id:sync_id
-	parts := strings.Split(d.Id(), ":")
-
-	if len(parts) == 2 {
-		// Format: workspace_id:sync_id
-		workspaceId := parts[0]
-		syncId := parts[1]
-
-		d.SetId(syncId)
-		d.Set("workspace_id", workspaceId)
-
-		return []*schema.ResourceData{d}, nil
-	} else if len(parts) == 1 {
+	// Support composite formats: workspace_id:sync_id and workspace_id:name=<label>
+	workspaceId, ref, ok := splitImportID(d.Id())
+	if !ok {
 		// Legacy format - provide helpful error
-		return nil, fmt.Errorf(`import requires workspace_id. Use format: workspace_id:sync_id
+		return nil, fmt.Errorf(`import requires workspace_id. Use format: workspace_id:sync_id or workspace_id:name=<label>
 
 Example:
   terraform import census_sync.contact_sync 69962:123
+  terraform import census_sync.contact_sync "69962:name=Contact sync"
+
+Where 69962 is the workspace_id and 123 is the sync_id. A label must match exactly one sync in the workspace.`)
+	}
 
-Where 69962 is the workspace_id and 123 is the sync_id.`)
+	syncId, err := resolveImportRef(ctx, meta, workspaceId, ref, "sync", "label", (*client.Client).ListAllSyncsWithToken,
+		func(item client.Sync) string { return item.Label },
+		func(item client.Sync) int { return item.ID })
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("invalid import format. Use: workspace_id:sync_id")
+	d.SetId(syncId)
+	d.Set("workspace_id", workspaceId)
+
+	return []*schema.ResourceData{d}, nil
 }
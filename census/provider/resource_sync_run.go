@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+const (
+	// defaultSyncRunTimeout is how long create waits for a sync run to finish by default
+	defaultSyncRunTimeout = 60 * time.Minute
+	// syncRunCancelTimeout bounds the cancel request sent after the create timeout has expired
+	syncRunCancelTimeout = 30 * time.Second
+)
+
+// terminalSyncRunStatuses are the sync run statuses after which a run will not change again
+var terminalSyncRunStatuses = map[string]bool{
+	"completed": true,
+	"failed":    true,
+	"cancelled": true,
+	"canceled":  true,
+	"skipped":   true,
+}
+
+func resourceSyncRun() *schema.Resource {
+	return &schema.Resource{
+		Description: "Triggers a run of a Census sync and optionally waits for it to finish. " +
+			"A new run is started whenever sync_id, force_full_sync or triggers change.",
+
+		CreateContext: resourceSyncRunCreate,
+		ReadContext:   resourceSyncRunRead,
+		UpdateContext: resourceSyncRunUpdate,
+		DeleteContext: resourceSyncRunDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSyncRunImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultSyncRunTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the sync run.",
+			},
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the workspace the sync belongs to.",
+			},
+			"sync_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the sync to run.",
+			},
+			"force_full_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether to resync all records instead of only changed ones.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values that, when changed, start a new sync run. Use it to rerun a sync after a dependent change, such as a field mapping update.",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to wait for the run to reach a terminal status before finishing the apply.",
+			},
+			"failure_threshold_percent": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.FloatBetween(0, 100),
+				Description:  "Fail the apply when the percentage of failed records is above this value. Only checked when wait_for_completion is true. Defaults to 100, which never fails because of rejected records.",
+			},
+			"poll_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of seconds between status checks while waiting for the run to finish.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the sync run.",
+			},
+			"error_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The error message of a failed sync run.",
+			},
+			"records_processed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of records processed by the run.",
+			},
+			"records_succeeded": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of records successfully synced by the run.",
+			},
+			"records_failed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of records rejected by the destination.",
+			},
+			"failure_rate_percent": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The percentage of processed records that failed.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp when the run was created.",
+			},
+			"started_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp when the run started.",
+			},
+			"completed_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp when the run finished.",
+			},
+		},
+	}
+}
+
+func resourceSyncRunCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	workspaceId := d.Get("workspace_id").(string)
+	syncIdStr := d.Get("sync_id").(string)
+	ctx = syncLogContext(ctx, workspaceId, syncIdStr)
+
+	syncId, err := strconv.Atoi(syncIdStr)
+	if err != nil {
+		return diag.Errorf("invalid sync ID: %s", syncIdStr)
+	}
+
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	req := &client.TriggerSyncRequest{
+		ForceFullSync: d.Get("force_full_sync").(bool),
+	}
+
+	runId, err := apiClient.TriggerSyncWithToken(ctx, syncId, req, workspaceToken)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(runId))
+	ctx = tflog.SubsystemSetField(ctx, syncLogSubsystem, "sync_run_id", runId)
+	tflog.SubsystemInfo(ctx, syncLogSubsystem, "Triggered sync run", map[string]interface{}{
+		"force_full_sync": req.ForceFullSync,
+	})
+
+	if !d.Get("wait_for_completion").(bool) {
+		return resourceSyncRunRead(ctx, d, meta)
+	}
+
+	interval := time.Duration(d.Get("poll_interval").(int)) * time.Second
+	run, err := waitForSyncRun(ctx, apiClient, runId, workspaceToken, interval)
+	if run != nil {
+		setSyncRunAttributes(d, run)
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			return diag.FromErr(err)
+		}
+
+		// The create timeout expired - stop the run instead of leaving it running unattended
+		cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), syncRunCancelTimeout)
+		defer cancel()
+		if cancelErr := apiClient.CancelSyncRunWithToken(cancelCtx, runId, workspaceToken); cancelErr != nil {
+			return diag.Errorf("sync run %d did not finish within %s and could not be cancelled: %v",
+				runId, d.Timeout(schema.TimeoutCreate), cancelErr)
+		}
+		return diag.Errorf("sync run %d did not finish within %s and has been cancelled", runId, d.Timeout(schema.TimeoutCreate))
+	}
+
+	return checkSyncRunResult(run, d.Get("failure_threshold_percent").(float64))
+}
+
+func resourceSyncRunRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*client.Client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("invalid sync run ID: %s", d.Id())
+	}
+
+	workspaceId := d.Get("workspace_id").(string)
+	if workspaceId == "" {
+		return diag.Errorf("workspace_id is required but missing from resource state")
+	}
+
+	workspaceToken, diags := getWorkspaceToken(ctx, apiClient, workspaceId)
+	if diags.HasError() {
+		return diags
+	}
+
+	run, err := apiClient.GetSyncRunWithToken(ctx, id, workspaceToken)
+	if err != nil {
+		if IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if run == nil {
+		d.SetId("")
+		return nil
+	}
+
+	setSyncRunAttributes(d, run)
+	return nil
+}
+
+func resourceSyncRunUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only wait_for_completion, failure_threshold_percent and poll_interval can change in place,
+	// and they only affect how a new run is started
+	return resourceSyncRunRead(ctx, d, meta)
+}
+
+func resourceSyncRunDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// A finished sync run cannot be deleted; removing it from state is enough
+	d.SetId("")
+	return nil
+}
+
+func resourceSyncRunImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Support composite format: workspace_id:sync_run_id
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid import format. Use: workspace_id:sync_run_id")
+	}
+
+	d.SetId(parts[1])
+	d.Set("workspace_id", parts[0])
+	d.Set("force_full_sync", false)
+	d.Set("wait_for_completion", true)
+	d.Set("failure_threshold_percent", 100)
+	d.Set("poll_interval", 10)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForSyncRun polls a sync run until it reaches a terminal status or the context is done.
+// The last run fetched is returned alongside any error.
+func waitForSyncRun(ctx context.Context, apiClient *client.Client, runId int, workspaceToken string, interval time.Duration) (*client.SyncRun, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *client.SyncRun
+	for {
+		run, err := apiClient.GetSyncRunWithToken(ctx, runId, workspaceToken)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				return last, ctx.Err()
+			}
+			return last, fmt.Errorf("failed to check status of sync run %d: %w", runId, err)
+		}
+		if run != nil {
+			last = run
+			tflog.SubsystemDebug(ctx, syncLogSubsystem, "Polled sync run", map[string]interface{}{
+				"status":            run.Status,
+				"records_processed": run.RecordsProcessed,
+			})
+			if IsTerminalSyncRunStatus(run.Status) {
+				return run, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkSyncRunResult reports an error for runs that did not complete or rejected too many records
+func checkSyncRunResult(run *client.SyncRun, failureThresholdPercent float64) diag.Diagnostics {
+	switch strings.ToLower(run.Status) {
+	case "failed":
+		if run.ErrorMessage != "" {
+			return diag.Errorf("sync run %d failed: %s", run.ID, run.ErrorMessage)
+		}
+		return diag.Errorf("sync run %d failed", run.ID)
+	case "cancelled", "canceled":
+		return diag.Errorf("sync run %d was cancelled", run.ID)
+	}
+
+	if rate := SyncRunFailureRate(run); rate > failureThresholdPercent {
+		return diag.Errorf("sync run %d rejected %d of %d records (%.2f%%), above the failure threshold of %.2f%%",
+			run.ID, run.RecordsFailed, run.RecordsProcessed, rate, failureThresholdPercent)
+	}
+
+	return nil
+}
+
+// setSyncRunAttributes copies the status and record counts of a sync run into state
+func setSyncRunAttributes(d *schema.ResourceData, run *client.SyncRun) {
+	if run.SyncID != 0 {
+		d.Set("sync_id", strconv.Itoa(run.SyncID))
+	}
+	d.Set("status", run.Status)
+	d.Set("error_message", run.ErrorMessage)
+	d.Set("records_processed", run.RecordsProcessed)
+	d.Set("records_succeeded", run.RecordsSucceeded)
+	d.Set("records_failed", run.RecordsFailed)
+	d.Set("failure_rate_percent", SyncRunFailureRate(run))
+
+	if !run.CreatedAt.IsZero() {
+		d.Set("created_at", run.CreatedAt.Format("2006-01-02T15:04:05Z"))
+	}
+	if run.StartedAt != nil {
+		d.Set("started_at", run.StartedAt.Format("2006-01-02T15:04:05Z"))
+	}
+	if run.CompletedAt != nil {
+		d.Set("completed_at", run.CompletedAt.Format("2006-01-02T15:04:05Z"))
+	}
+}
+
+// IsTerminalSyncRunStatus reports whether a sync run with the given status has finished
+func IsTerminalSyncRunStatus(status string) bool {
+	return terminalSyncRunStatuses[strings.ToLower(status)]
+}
+
+// SyncRunFailureRate returns the percentage of processed records that failed in a sync run
+func SyncRunFailureRate(run *client.SyncRun) float64 {
+	if run == nil || run.RecordsProcessed <= 0 {
+		return 0
+	}
+	return float64(run.RecordsFailed) / float64(run.RecordsProcessed) * 100
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// resourceSyncV0 is the census_sync schema of provider 0.2.0 and earlier, before field_mapping became a
+// set and next_runs was added
+func resourceSyncV0() *schema.Resource {
+	s := resourceSyncSchema()
+	s["field_mapping"].Type = schema.TypeList
+	s["field_mapping"].Set = nil
+	delete(s, "next_runs")
+	return &schema.Resource{Schema: s}
+}
+
+// resourceSyncStateUpgradeV0 moves field_mapping from a list to a set. Lists and sets share the same
+// JSON state encoding, so the mappings carry over unchanged and are rehashed when the state is decoded
+// with the current schema. Their order no longer matters, so no diff is planned. next_runs is left
+// unset until the refresh that precedes the plan.
+func resourceSyncStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	return rawState, nil
+}
+
+// LegacySyncMode returns the run mode of a sync, converting the flat schedule fields or schedule
+// object of syncs created before the API supported modes. It returns nil when the sync has neither.
+func LegacySyncMode(sync *client.Sync) *client.SyncMode {
+	switch {
+	case sync.Mode != nil:
+		return sync.Mode
+	case sync.ScheduleFrequency != "":
+		return LegacyScheduleMode(sync.ScheduleFrequency, sync.ScheduleDay, sync.ScheduleHour, sync.ScheduleMinute, sync.CronExpression)
+	case sync.Schedule != nil && sync.Schedule.Frequency != "":
+		day, hour, minute := sync.Schedule.DayOfWeek, sync.Schedule.Hour, sync.Schedule.Minute
+		return LegacyScheduleMode(sync.Schedule.Frequency, &day, &hour, &minute, "")
+	default:
+		return nil
+	}
+}
+
+// LegacyScheduleMode builds the triggered run mode equivalent to a legacy schedule. day is a day of
+// the week counted from Sunday = 0 and only applies to weekly schedules.
+func LegacyScheduleMode(frequency string, day, hour, minute *int, cronExpression string) *client.SyncMode {
+	if frequency == "" {
+		return nil
+	}
+
+	schedule := &client.TriggerSchedule{Frequency: frequency}
+	if frequency == "expression" {
+		schedule.CronExpression = cronExpression
+	}
+	if frequency == "weekly" && day != nil && *day >= 0 && *day <= 6 {
+		schedule.Day = time.Weekday(*day).String()
+	}
+	if (frequency == "daily" || frequency == "weekly") && hour != nil {
+		schedule.Hour = *hour
+	}
+	if frequency != "expression" && frequency != "never" && frequency != "continuous" && minute != nil {
+		schedule.Minute = *minute
+	}
+
+	return &client.SyncMode{
+		Type:     "triggered",
+		Triggers: &client.SyncTriggers{Schedule: schedule},
+	}
+}
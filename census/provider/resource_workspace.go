@@ -20,7 +20,7 @@ func resourceWorkspace() *schema.Resource {
 		DeleteContext: resourceWorkspaceDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceWorkspaceImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -82,7 +82,7 @@ func resourceWorkspaceCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 	workspace, err := apiClient.CreateWorkspace(ctx, req)
 	if err != nil {
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceWorkspace().Schema, nil)
 	}
 
 	d.SetId(strconv.Itoa(workspace.ID))
@@ -152,7 +152,7 @@ func resourceWorkspaceUpdate(ctx context.Context, d *schema.ResourceData, meta i
 
 	_, err = apiClient.UpdateWorkspace(ctx, id, req)
 	if err != nil {
-		return diag.FromErr(err)
+		return APIErrorDiagnostics(err, resourceWorkspace().Schema, nil)
 	}
 
 	return resourceWorkspaceRead(ctx, d, meta)
@@ -187,3 +187,10 @@ func expandStringSet(set *schema.Set) []string {
 	}
 	return result
 }
+
+func resourceWorkspaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// return_workspace_api_key only applies on create; its default is imported so the next plan is empty
+	d.Set("return_workspace_api_key", false)
+
+	return []*schema.ResourceData{d}, nil
+}
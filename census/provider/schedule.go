@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider/cron"
+)
+
+// nextRunsPreviewCount is the number of upcoming fire times listed in next_runs
+const nextRunsPreviewCount = 5
+
+// scheduleAttributePath is the path of the schedule block that schedule validation errors are reported against
+var scheduleAttributePath = cty.GetAttrPath("run_mode").IndexInt(0).GetAttr("triggers").IndexInt(0).GetAttr("schedule").IndexInt(0)
+
+var weekdays = map[string]time.Weekday{
+	"Sunday": time.Sunday, "Monday": time.Monday, "Tuesday": time.Tuesday, "Wednesday": time.Wednesday,
+	"Thursday": time.Thursday, "Friday": time.Friday, "Saturday": time.Saturday,
+}
+
+// ValidateSchedule checks that the day, hour, minute and cron_expression set on a schedule are
+// valid for its frequency. Zero values are treated as unset, matching how they are sent to the API.
+// Each problem is reported against the schedule attribute it concerns.
+func ValidateSchedule(schedule *client.TriggerSchedule) diag.Diagnostics {
+	if schedule == nil {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	forbid := func(attribute string, set bool) {
+		if set {
+			diags = append(diags, pathErrorf(scheduleAttributePath.GetAttr(attribute), "%s cannot be set when frequency is %q", attribute, schedule.Frequency))
+		}
+	}
+
+	if schedule.Frequency != "expression" {
+		forbid("cron_expression", schedule.CronExpression != "")
+	}
+
+	switch schedule.Frequency {
+	case "expression":
+		if schedule.CronExpression == "" {
+			diags = append(diags, pathErrorf(scheduleAttributePath.GetAttr("cron_expression"), "cron_expression is required when frequency is \"expression\""))
+		} else {
+			diags = append(diags, validateCronExpression(schedule.CronExpression, scheduleAttributePath.GetAttr("cron_expression"))...)
+		}
+		forbid("day", schedule.Day != "")
+		forbid("hour", schedule.Hour != 0)
+		forbid("minute", schedule.Minute != 0)
+	case "weekly":
+		if schedule.Day == "" {
+			diags = append(diags, pathErrorf(scheduleAttributePath.GetAttr("day"), "day is required when frequency is \"weekly\""))
+		}
+	case "daily":
+		forbid("day", schedule.Day != "")
+	case "hourly", "quarter_hourly":
+		forbid("day", schedule.Day != "")
+		forbid("hour", schedule.Hour != 0)
+	case "never", "continuous":
+		forbid("day", schedule.Day != "")
+		forbid("hour", schedule.Hour != 0)
+		forbid("minute", schedule.Minute != 0)
+	}
+
+	return diags
+}
+
+// validateCronExpression is the ValidateDiagFunc of cron_expression, so a malformed expression is
+// reported against the attribute by terraform validate
+func validateCronExpression(v interface{}, path cty.Path) diag.Diagnostics {
+	expr, _ := v.(string)
+	if expr == "" {
+		return nil
+	}
+	if err := cron.Validate(expr); err != nil {
+		return diag.Diagnostics{pathErrorf(path, "invalid cron_expression: %v", err)}
+	}
+	return nil
+}
+
+// ScheduleCronExpression returns the cron expression equivalent to a schedule, or an empty string
+// for frequencies without fixed fire times (never and continuous)
+func ScheduleCronExpression(schedule *client.TriggerSchedule) string {
+	if schedule == nil {
+		return ""
+	}
+
+	// Hours are accepted up to 24, which is midnight
+	hour := schedule.Hour % 24
+	switch schedule.Frequency {
+	case "expression":
+		return schedule.CronExpression
+	case "quarter_hourly":
+		return fmt.Sprintf("%d/15 * * * *", schedule.Minute%15)
+	case "hourly":
+		return fmt.Sprintf("%d * * * *", schedule.Minute)
+	case "daily":
+		return fmt.Sprintf("%d %d * * *", schedule.Minute, hour)
+	case "weekly":
+		day, ok := weekdays[schedule.Day]
+		if !ok {
+			return ""
+		}
+		return fmt.Sprintf("%d %d * * %d", schedule.Minute, hour, day)
+	}
+	return ""
+}
+
+// NextScheduledRuns returns the next n fire times of a schedule after the given time as UTC
+// RFC 3339 timestamps. Schedules without fixed fire times or with an invalid expression return none.
+func NextScheduledRuns(schedule *client.TriggerSchedule, after time.Time, n int) []string {
+	expr := ScheduleCronExpression(schedule)
+	if expr == "" {
+		return []string{}
+	}
+	parsed, err := cron.Parse(expr)
+	if err != nil {
+		return []string{}
+	}
+
+	runs := make([]string, 0, n)
+	for _, t := range parsed.NextN(after, n) {
+		runs = append(runs, t.Format(time.RFC3339))
+	}
+	return runs
+}
+
+// syncSchedule returns the schedule trigger of a sync mode, or nil
+func syncSchedule(mode *client.SyncMode) *client.TriggerSchedule {
+	if mode == nil || mode.Triggers == nil || !strings.EqualFold(mode.Type, "triggered") {
+		return nil
+	}
+	return mode.Triggers.Schedule
+}
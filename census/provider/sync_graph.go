@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// SyncGraphNode is a sync in a workspace's sync_sequence trigger graph
+type SyncGraphNode struct {
+	ID     int
+	Label  string
+	Paused bool
+}
+
+// SyncGraphEdge connects a sync to a sync it triggers: To runs after From completes
+type SyncGraphEdge struct {
+	From int
+	To   int
+}
+
+// SyncGraph is the graph of sync_sequence triggers in a workspace. Each sync has at most one
+// sync_sequence trigger, so every node has at most one upstream sync.
+type SyncGraph struct {
+	Nodes map[int]SyncGraphNode
+
+	// upstream maps a sync to the sync whose completion triggers it
+	upstream map[int]int
+}
+
+// BuildSyncGraph builds the trigger graph of a workspace's syncs
+func BuildSyncGraph(syncs []client.Sync) *SyncGraph {
+	g := &SyncGraph{
+		Nodes:    make(map[int]SyncGraphNode, len(syncs)),
+		upstream: make(map[int]int),
+	}
+	for _, sync := range syncs {
+		g.Nodes[sync.ID] = SyncGraphNode{ID: sync.ID, Label: sync.Label, Paused: sync.Paused}
+		if upstream := syncSequenceUpstream(sync.Mode); upstream != 0 {
+			g.upstream[sync.ID] = upstream
+		}
+	}
+	return g
+}
+
+// SetUpstream replaces the sync_sequence trigger of a sync, as when applying planned configuration.
+// An upstream of 0 removes the trigger.
+func (g *SyncGraph) SetUpstream(syncID, upstreamID int) {
+	if upstreamID == 0 {
+		delete(g.upstream, syncID)
+		return
+	}
+	g.upstream[syncID] = upstreamID
+}
+
+// Upstream returns the sync that triggers syncID, or 0
+func (g *SyncGraph) Upstream(syncID int) int {
+	return g.upstream[syncID]
+}
+
+// Edges returns every trigger edge, ordered by the triggered sync's ID
+func (g *SyncGraph) Edges() []SyncGraphEdge {
+	edges := make([]SyncGraphEdge, 0, len(g.upstream))
+	for to, from := range g.upstream {
+		edges = append(edges, SyncGraphEdge{From: from, To: to})
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].To < edges[j].To })
+	return edges
+}
+
+// DanglingEdges returns the edges whose upstream sync is not in the graph, for example because it was deleted
+func (g *SyncGraph) DanglingEdges() []SyncGraphEdge {
+	var dangling []SyncGraphEdge
+	for _, edge := range g.Edges() {
+		if _, ok := g.Nodes[edge.From]; !ok {
+			dangling = append(dangling, edge)
+		}
+	}
+	return dangling
+}
+
+// FindCycle returns the cycle syncID is part of, in trigger order starting and ending with syncID,
+// or nil when following its upstream syncs does not lead back to it
+func (g *SyncGraph) FindCycle(syncID int) []int {
+	path := []int{syncID}
+	seen := map[int]bool{syncID: true}
+	for current := g.upstream[syncID]; current != 0; current = g.upstream[current] {
+		if current == syncID {
+			// path runs downstream to upstream, reverse it into trigger order
+			cycle := []int{syncID}
+			for i := len(path) - 1; i >= 0; i-- {
+				cycle = append(cycle, path[i])
+			}
+			return cycle
+		}
+		if seen[current] {
+			// A cycle further upstream that syncID only feeds into
+			return nil
+		}
+		seen[current] = true
+		path = append(path, current)
+	}
+	return nil
+}
+
+// TopologicalOrder returns the sync IDs ordered so that every sync comes after the sync that triggers
+// it. Syncs with no upstream come first, and ties are broken by ID. It fails if the graph has a cycle.
+func (g *SyncGraph) TopologicalOrder() ([]int, error) {
+	downstream := make(map[int][]int)
+	indegree := make(map[int]int, len(g.Nodes))
+	for id := range g.Nodes {
+		indegree[id] = 0
+	}
+	for to, from := range g.upstream {
+		if _, ok := g.Nodes[from]; !ok {
+			continue
+		}
+		if _, ok := g.Nodes[to]; !ok {
+			continue
+		}
+		downstream[from] = append(downstream[from], to)
+		indegree[to]++
+	}
+
+	var ready []int
+	for id, degree := range indegree {
+		if degree == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := make([]int, 0, len(g.Nodes))
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+		for _, next := range downstream[id] {
+			indegree[next]--
+			if indegree[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+
+	if len(order) < len(g.Nodes) {
+		ids := make([]int, 0, len(g.Nodes))
+		for id := range g.Nodes {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		for _, id := range ids {
+			if cycle := g.FindCycle(id); cycle != nil {
+				return nil, fmt.Errorf("sync_sequence triggers form a cycle: %s", FormatSyncCycle(cycle))
+			}
+		}
+		return nil, fmt.Errorf("sync_sequence triggers form a cycle")
+	}
+	return order, nil
+}
+
+// FormatSyncCycle renders a cycle as 1 -> 2 -> 1
+func FormatSyncCycle(cycle []int) string {
+	ids := make([]string, len(cycle))
+	for i, id := range cycle {
+		ids[i] = strconv.Itoa(id)
+	}
+	return strings.Join(ids, " -> ")
+}
+
+// syncSequenceUpstream returns the sync a sync mode's sync_sequence trigger waits for, or 0
+func syncSequenceUpstream(mode *client.SyncMode) int {
+	if mode == nil || mode.Triggers == nil || mode.Triggers.SyncSequence == nil || !strings.EqualFold(mode.Type, "triggered") {
+		return 0
+	}
+	return mode.Triggers.SyncSequence.SyncId
+}
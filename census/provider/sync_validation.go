@@ -0,0 +1,461 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// Paths that sync plan validation errors are reported against. field_mapping is a set, and the plugin
+// protocol cannot address set elements from the SDK, so mapping errors point at field_mapping and name the
+// mapping by its to field.
+var (
+	fieldMappingAttributePath  = cty.GetAttrPath("field_mapping")
+	highWaterMarkAttributePath = cty.GetAttrPath("high_water_mark_attribute")
+	syncSequenceAttributePath  = cty.GetAttrPath("run_mode").IndexInt(0).GetAttr("triggers").IndexInt(0).GetAttr("sync_sequence").IndexInt(0).GetAttr("sync_id")
+)
+
+// resourceSyncCustomizeDiff checks the run_mode schedule and sync_sequence trigger, and checks field_mapping and high_water_mark_attribute
+// against the destination object's fields and the source object's columns during plan. Each catalog check is
+// skipped when its inputs are not known yet, when nothing relevant changed, or when the catalog it needs
+// cannot be read, so that a plan never fails on the check itself.
+func resourceSyncCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("run_mode") {
+		if err := d.SetNewComputed("next_runs"); err != nil {
+			return err
+		}
+	}
+	if err := clearMirrorStrategy(d); err != nil {
+		return err
+	}
+
+	var diags diag.Diagnostics
+	diags = append(diags, validateUniqueFieldMappingTargets(d)...)
+	diags = append(diags, validateSyncSchedule(d)...)
+	diags = append(diags, validateSyncCatalog(ctx, d, meta)...)
+	diags = append(diags, validateSyncSequenceTrigger(ctx, d, meta)...)
+	return planValidationError(diags)
+}
+
+// clearMirrorStrategy plans mirror_strategy as cleared when the sync is no longer a mirror sync and the
+// configuration does not set it. mirror_strategy is computed because the API picks one for mirror syncs,
+// so removing it from the configuration alone would keep the old strategy.
+func clearMirrorStrategy(d *schema.ResourceDiff) error {
+	if d.Get("operation").(string) == "mirror" || d.Get("mirror_strategy").(string) == "" {
+		return nil
+	}
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.GetAttr("mirror_strategy").IsNull() {
+		return nil
+	}
+	return d.SetNew("mirror_strategy", "")
+}
+
+// planValidationError converts plan validation diagnostics into the error a CustomizeDiff returns. The SDK
+// reports a cty.PathError against its attribute, but only one error can be returned, so the first
+// diagnostic carries its path and any others are appended to the message with theirs.
+func planValidationError(diags diag.Diagnostics) error {
+	if len(diags) == 0 {
+		return nil
+	}
+	message := diags[0].Summary
+	for _, d := range diags[1:] {
+		message += fmt.Sprintf("\n%s: %s", FormatAttributePath(d.AttributePath), d.Summary)
+	}
+	return diags[0].AttributePath.NewErrorf("%s", message)
+}
+
+// FormatAttributePath renders an attribute path the way it is written in validation messages, such as
+// run_mode[0].triggers[0].schedule[0].day
+func FormatAttributePath(path cty.Path) string {
+	var b strings.Builder
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(step.Name)
+		case cty.IndexStep:
+			switch step.Key.Type() {
+			case cty.Number:
+				i, _ := step.Key.AsBigFloat().Int64()
+				fmt.Fprintf(&b, "[%d]", i)
+			case cty.String:
+				fmt.Fprintf(&b, "[%q]", step.Key.AsString())
+			}
+		}
+	}
+	return b.String()
+}
+
+// pathErrorf returns an error diagnostic for the attribute at path. The path is copied, since the SDK
+// reuses the path it passes to ValidateDiagFunc while validating sibling attributes.
+func pathErrorf(path cty.Path, format string, a ...interface{}) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf(format, a...),
+		AttributePath: path.Copy(),
+	}
+}
+
+// validateUniqueFieldMappingTargets reports destination fields mapped more than once. Validation errors
+// identify mappings by their to field, so it must be unique. The raw config is used since identical
+// mappings have already been collapsed into one by the time the set is read with d.Get.
+func validateUniqueFieldMappingTargets(d *schema.ResourceDiff) diag.Diagnostics {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	mappings := config.GetAttr("field_mapping")
+	if mappings.IsNull() || !mappings.IsKnown() {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	counts := make(map[string]int)
+	for it := mappings.ElementIterator(); it.Next(); {
+		_, mapping := it.Element()
+		to := mapping.GetAttr("to")
+		if to.IsNull() || !to.IsKnown() {
+			continue
+		}
+		counts[to.AsString()]++
+		if counts[to.AsString()] == 2 {
+			diags = append(diags, pathErrorf(fieldMappingAttributePath, "destination field %q is mapped more than once", to.AsString()))
+		}
+	}
+	return diags
+}
+
+// validateSyncSchedule runs ValidateSchedule for the planned schedule trigger once all of its values are known
+func validateSyncSchedule(d *schema.ResourceDiff) diag.Diagnostics {
+	const schedule = "run_mode.0.triggers.0.schedule.0."
+	for _, attribute := range []string{"frequency", "day", "hour", "minute", "cron_expression"} {
+		if !d.NewValueKnown(schedule + attribute) {
+			return nil
+		}
+	}
+	return ValidateSchedule(syncSchedule(ExpandRunMode(d.Get("run_mode").([]interface{}))))
+}
+
+// validateSyncCatalog checks field_mapping and high_water_mark_attribute against the workspace catalog
+func validateSyncCatalog(ctx context.Context, d *schema.ResourceDiff, meta interface{}) diag.Diagnostics {
+	apiClient, ok := meta.(*client.Client)
+	if !ok || apiClient == nil {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("field_mapping", "source_attributes", "destination_attributes", "high_water_mark_attribute") {
+		return nil
+	}
+	if !d.NewValueKnown("field_mapping") {
+		return nil
+	}
+
+	ctx, workspaceToken, ok := syncPlanWorkspaceToken(ctx, d, apiClient)
+	if !ok {
+		return nil
+	}
+
+	mappings := ExpandFieldMappings(d.Get("field_mapping").(*schema.Set).List())
+	return append(
+		validateSyncDestinationFields(ctx, d, apiClient, workspaceToken, mappings),
+		validateSyncSourceColumns(ctx, d, apiClient, workspaceToken, mappings)...,
+	)
+}
+
+// validateSyncSequenceTrigger runs ValidateSyncSequenceTrigger for a planned sync_sequence trigger against the
+// workspace's current syncs. A CustomizeDiff only sees the plan of its own resource, so cycles made of
+// several syncs created or changed in the same plan are not detected.
+func validateSyncSequenceTrigger(ctx context.Context, d *schema.ResourceDiff, meta interface{}) diag.Diagnostics {
+	apiClient, ok := meta.(*client.Client)
+	if !ok || apiClient == nil {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("run_mode") {
+		return nil
+	}
+	if !d.NewValueKnown("run_mode.0.type") || !d.NewValueKnown("run_mode.0.triggers.0.sync_sequence.0.sync_id") {
+		return nil
+	}
+	upstreamID := syncSequenceUpstream(ExpandRunMode(d.Get("run_mode").([]interface{})))
+	if upstreamID == 0 {
+		return nil
+	}
+
+	ctx, workspaceToken, ok := syncPlanWorkspaceToken(ctx, d, apiClient)
+	if !ok {
+		return nil
+	}
+
+	syncs, err := apiClient.ListAllSyncsWithToken(ctx, nil, workspaceToken)
+	if err != nil {
+		tflog.SubsystemWarn(ctx, syncLogSubsystem, "Skipping sync_sequence validation, could not list syncs", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+
+	// A sync being created has no ID yet, so nothing can trigger it and it cannot close a cycle
+	syncID, _ := strconv.Atoi(d.Id())
+	return ValidateSyncSequenceTrigger(BuildSyncGraph(syncs), syncID, upstreamID)
+}
+
+// syncPlanWorkspaceToken returns the cached API key of the planned workspace, and a context carrying the
+// sync's log fields. It returns false when the workspace is not known yet or its key cannot be fetched.
+func syncPlanWorkspaceToken(ctx context.Context, d *schema.ResourceDiff, apiClient *client.Client) (context.Context, string, bool) {
+	if !d.NewValueKnown("workspace_id") {
+		return ctx, "", false
+	}
+	workspaceId := d.Get("workspace_id").(string)
+	workspaceIdInt, err := strconv.Atoi(workspaceId)
+	if err != nil {
+		return ctx, "", false
+	}
+	ctx = syncLogContext(ctx, workspaceId, d.Id())
+
+	workspaceToken, err := apiClient.GetCachedWorkspaceAPIKey(ctx, workspaceIdInt)
+	if err != nil {
+		tflog.SubsystemWarn(ctx, syncLogSubsystem, "Skipping sync plan validation, could not get workspace API key", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return ctx, "", false
+	}
+	return ctx, workspaceToken, true
+}
+
+// validateSyncDestinationFields runs ValidateFieldMappingsAgainstDestination for the planned destination object
+func validateSyncDestinationFields(ctx context.Context, d *schema.ResourceDiff, apiClient *client.Client, workspaceToken string, mappings []client.FieldMapping) diag.Diagnostics {
+	if !d.NewValueKnown("destination_attributes.0.connection_id") || !d.NewValueKnown("destination_attributes.0.object") {
+		return nil
+	}
+	connectionID := d.Get("destination_attributes.0.connection_id").(int)
+	objectName := d.Get("destination_attributes.0.object").(string)
+	if connectionID == 0 || objectName == "" {
+		return nil
+	}
+
+	objects, err := apiClient.GetDestinationObjectsWithToken(ctx, connectionID, workspaceToken)
+	if err != nil {
+		tflog.SubsystemWarn(ctx, syncLogSubsystem, "Skipping field_mapping validation, could not list destination objects", map[string]interface{}{
+			"destination_id": connectionID,
+			"error":          err.Error(),
+		})
+		return nil
+	}
+
+	return ValidateFieldMappingsAgainstDestination(objects, objectName, mappings)
+}
+
+// validateSyncSourceColumns runs ValidateSourceColumns for planned dataset and table sources
+func validateSyncSourceColumns(ctx context.Context, d *schema.ResourceDiff, apiClient *client.Client, workspaceToken string, mappings []client.FieldMapping) diag.Diagnostics {
+	const object = "source_attributes.0.object.0."
+	if !d.NewValueKnown("source_attributes.0.connection_id") || !d.NewValueKnown(object+"type") || !d.NewValueKnown("high_water_mark_attribute") {
+		return nil
+	}
+	connectionID := d.Get("source_attributes.0.connection_id").(int)
+	objectType := d.Get(object + "type").(string)
+
+	var (
+		columns     []client.SourceColumn
+		description string
+		err         error
+	)
+	switch objectType {
+	case "dataset":
+		if !d.NewValueKnown(object + "id") {
+			return nil
+		}
+		datasetID, convErr := strconv.Atoi(d.Get(object + "id").(string))
+		if convErr != nil {
+			return nil
+		}
+		var dataset *client.Dataset
+		dataset, err = apiClient.GetDatasetWithToken(ctx, datasetID, workspaceToken)
+		if err == nil && dataset != nil {
+			for _, column := range dataset.Columns {
+				columns = append(columns, client.SourceColumn{Name: column.Name, DataType: column.DataType})
+			}
+		}
+		description = fmt.Sprintf("dataset %d", datasetID)
+	case "table":
+		if connectionID == 0 || !d.NewValueKnown(object+"table_name") || !d.NewValueKnown(object+"table_schema") || !d.NewValueKnown(object+"table_catalog") {
+			return nil
+		}
+		tableName := d.Get(object + "table_name").(string)
+		if tableName == "" {
+			return nil
+		}
+		columns, err = apiClient.GetSourceTableColumnsWithToken(ctx, connectionID,
+			d.Get(object+"table_catalog").(string), d.Get(object+"table_schema").(string), tableName, workspaceToken)
+		description = fmt.Sprintf("table %q", tableName)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		tflog.SubsystemWarn(ctx, syncLogSubsystem, "Skipping source column validation, could not read the source object's columns", map[string]interface{}{
+			"source_id":   connectionID,
+			"object_type": objectType,
+			"error":       err.Error(),
+		})
+		return nil
+	}
+
+	return ValidateSourceColumns(columns, description, mappings, d.Get("high_water_mark_attribute").(string))
+}
+
+// FindDestinationObject returns the object whose ID, name or full name equals name, or nil
+func FindDestinationObject(objects []client.DestinationObject, name string) *client.DestinationObject {
+	for i := range objects {
+		if objects[i].ID == name || objects[i].Name == name || objects[i].FullName == name {
+			return &objects[i]
+		}
+	}
+	return nil
+}
+
+// ValidateFieldMappingsAgainstDestination reports field mappings whose to field does not exist on the
+// destination object, required destination fields that are not mapped, and lookup_object/lookup_field
+// pairs that do not exist, each against field_mapping. Empty values are treated as not yet known and
+// skipped. Nothing is reported when the object is not in the catalog or the catalog has no fields for it,
+// since the catalog may be stale or the destination may not describe its fields.
+func ValidateFieldMappingsAgainstDestination(objects []client.DestinationObject, objectName string, mappings []client.FieldMapping) diag.Diagnostics {
+	object := FindDestinationObject(objects, objectName)
+	if object == nil || len(object.Fields) == 0 {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	mapped := make(map[string]bool)
+	allTargetsKnown := true
+
+	for _, mapping := range mappings {
+		if mapping.To == "" {
+			allTargetsKnown = false
+		} else {
+			mapped[strings.ToLower(mapping.To)] = true
+			if !mapping.GenerateField && findDestinationField(object, mapping.To) == nil {
+				diags = append(diags, pathErrorf(fieldMappingAttributePath, "destination object %q has no field %q; set generate_field = true to create it", objectName, mapping.To))
+			}
+		}
+
+		if mapping.LookupObject == "" {
+			continue
+		}
+		lookupObject := FindDestinationObject(objects, mapping.LookupObject)
+		if lookupObject == nil {
+			diags = append(diags, pathErrorf(fieldMappingAttributePath, "mapping to %q: lookup_object %q does not exist in the destination", mapping.To, mapping.LookupObject))
+			continue
+		}
+		if mapping.LookupField != "" && len(lookupObject.Fields) > 0 && findDestinationField(lookupObject, mapping.LookupField) == nil {
+			diags = append(diags, pathErrorf(fieldMappingAttributePath, "mapping to %q: lookup_field %q does not exist on destination object %q", mapping.To, mapping.LookupField, mapping.LookupObject))
+		}
+	}
+
+	if allTargetsKnown {
+		for _, field := range object.Fields {
+			if field.Required && !mapped[strings.ToLower(field.ID)] && !mapped[strings.ToLower(field.Name)] {
+				diags = append(diags, pathErrorf(fieldMappingAttributePath, "required field %q of destination object %q is not mapped", destinationFieldName(field), objectName))
+			}
+		}
+	}
+
+	return diags
+}
+
+// findDestinationField matches a field by ID or name, ignoring case so that a casing difference is not reported as a missing field
+func findDestinationField(object *client.DestinationObject, name string) *client.DestinationField {
+	for i := range object.Fields {
+		if strings.EqualFold(object.Fields[i].ID, name) || strings.EqualFold(object.Fields[i].Name, name) {
+			return &object.Fields[i]
+		}
+	}
+	return nil
+}
+
+func destinationFieldName(field client.DestinationField) string {
+	if field.ID != "" {
+		return field.ID
+	}
+	return field.Name
+}
+
+// ValidateSourceColumns reports column and hash mappings whose from column does not exist in the source
+// object against field_mapping, and a high_water_mark_attribute that is missing or is not a timestamp
+// column against that attribute. Columns are matched ignoring case since warehouses differ in how they
+// report identifiers. Nothing is reported when the column list is empty, which happens before a dataset
+// has been run or a table has been refreshed.
+func ValidateSourceColumns(columns []client.SourceColumn, sourceObject string, mappings []client.FieldMapping, highWaterMark string) diag.Diagnostics {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]client.SourceColumn, len(columns))
+	for _, column := range columns {
+		byName[strings.ToLower(column.Name)] = column
+	}
+
+	var diags diag.Diagnostics
+	for _, mapping := range mappings {
+		switch mapping.Type {
+		case "", "direct", "hash":
+		default:
+			continue
+		}
+		if mapping.From == "" {
+			continue
+		}
+		if _, ok := byName[strings.ToLower(mapping.From)]; !ok {
+			diags = append(diags, pathErrorf(fieldMappingAttributePath, "mapping to %q: source %s has no column %q", mapping.To, sourceObject, mapping.From))
+		}
+	}
+
+	if highWaterMark != "" {
+		column, ok := byName[strings.ToLower(highWaterMark)]
+		switch {
+		case !ok:
+			diags = append(diags, pathErrorf(highWaterMarkAttributePath, "source %s has no column %q", sourceObject, highWaterMark))
+		case !IsTimestampColumnType(column.DataType):
+			diags = append(diags, pathErrorf(highWaterMarkAttributePath, "column %q has type %q, a timestamp column is required", highWaterMark, column.DataType))
+		}
+	}
+
+	return diags
+}
+
+// IsTimestampColumnType reports whether a warehouse column type holds a timestamp, such as
+// TIMESTAMP_NTZ, timestamp with time zone or DATETIME. An unknown (empty) type is accepted.
+func IsTimestampColumnType(dataType string) bool {
+	t := strings.ToLower(dataType)
+	return t == "" || strings.Contains(t, "timestamp") || strings.Contains(t, "datetime")
+}
+
+// ValidateSyncSequenceTrigger reports a sync_sequence trigger that waits for a sync that does not exist or is
+// paused, or that would close a cycle of triggers, against its sync_id. syncID is the sync being planned,
+// or 0 for a new sync.
+func ValidateSyncSequenceTrigger(graph *SyncGraph, syncID, upstreamID int) diag.Diagnostics {
+	if syncID != 0 {
+		graph.SetUpstream(syncID, upstreamID)
+		if cycle := graph.FindCycle(syncID); cycle != nil {
+			return diag.Diagnostics{pathErrorf(syncSequenceAttributePath, "triggering after sync %d would create a cycle: %s", upstreamID, FormatSyncCycle(cycle))}
+		}
+	}
+
+	upstream, ok := graph.Nodes[upstreamID]
+	switch {
+	case !ok:
+		return diag.Diagnostics{pathErrorf(syncSequenceAttributePath, "sync %d does not exist in this workspace", upstreamID)}
+	case upstream.Paused:
+		return diag.Diagnostics{pathErrorf(syncSequenceAttributePath, "sync %d (%q) is paused, so this sync would never be triggered", upstreamID, upstream.Label)}
+	}
+	return nil
+}
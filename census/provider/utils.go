@@ -1,17 +1,32 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/sutrolabs/terraform-provider-census/census/client"
 )
 
 // IsNotFoundError checks if an error is a 404 Not Found error
 func IsNotFoundError(err error) bool {
-	if apiErr, ok := err.(*client.APIError); ok {
-		return apiErr.StatusCode == 404
-	}
-	return false
+	return errors.Is(err, client.ErrNotFound)
+}
+
+// connectionAPIFields maps source and destination API field names onto their schema attributes
+var connectionAPIFields = map[string]string{
+	"connection.credentials": "connection_config",
+	"connection.name":        "name",
+	"connection.type":        "type",
+	"credentials":            "connection_config",
 }
 
 // expandConnectionConfig converts Terraform map to the format expected by the API
@@ -36,3 +51,119 @@ func expandConnectionConfig(config map[string]interface{}) map[string]interface{
 
 	return result
 }
+
+// getWorkspaceToken parses a workspace ID and returns its API key from the client's shared token cache
+func getWorkspaceToken(ctx context.Context, apiClient *client.Client, workspaceId string) (string, diag.Diagnostics) {
+	workspaceIdInt, err := strconv.Atoi(workspaceId)
+	if err != nil {
+		return "", diag.Errorf("invalid workspace ID: %s", workspaceId)
+	}
+
+	workspaceToken, err := apiClient.GetCachedWorkspaceAPIKey(ctx, workspaceIdInt)
+	if err != nil {
+		return "", diag.Errorf("failed to get workspace API key for workspace %d: %v", workspaceIdInt, err)
+	}
+
+	return workspaceToken, nil
+}
+
+// APIErrorDiagnostics converts a client error into diagnostics. Field messages from a
+// client.ValidationError are attached to the matching attribute when the API field maps onto
+// resourceSchema; apiFields renames API field prefixes (such as "connection.credentials") to
+// schema attribute names. Any other error is returned as a single diagnostic.
+func APIErrorDiagnostics(err error, resourceSchema map[string]*schema.Schema, apiFields map[string]string) diag.Diagnostics {
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.FieldErrors) == 0 {
+		return diag.FromErr(err)
+	}
+
+	fields := make([]string, 0, len(validationErr.FieldErrors))
+	for field := range validationErr.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var diags diag.Diagnostics
+	for _, field := range fields {
+		detail := strings.Join(validationErr.FieldErrors[field], "; ")
+
+		path := attributePathForAPIField(field, resourceSchema, apiFields)
+		if path == nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Census API rejected the request",
+				Detail:   fmt.Sprintf("%s: %s", field, detail),
+			})
+			continue
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid value for %s", field),
+			Detail:        detail,
+			AttributePath: path,
+		})
+	}
+
+	return diags
+}
+
+// attributePathForAPIField resolves an API field name such as "mappings[0].to" to the
+// deepest matching attribute path in the schema, or nil when the field is not in the schema
+func attributePathForAPIField(field string, resourceSchema map[string]*schema.Schema, apiFields map[string]string) cty.Path {
+	field = strings.NewReplacer("[", ".", "]", "").Replace(field)
+
+	// Prefer the longest matching API prefix
+	matched := ""
+	for apiPrefix := range apiFields {
+		if (field == apiPrefix || strings.HasPrefix(field, apiPrefix+".")) && len(apiPrefix) > len(matched) {
+			matched = apiPrefix
+		}
+	}
+	if matched != "" {
+		field = apiFields[matched] + strings.TrimPrefix(field, matched)
+	}
+
+	segments := strings.Split(field, ".")
+	attr, ok := resourceSchema[segments[0]]
+	if !ok {
+		return nil
+	}
+	path := cty.GetAttrPath(segments[0])
+
+	for i := 1; i < len(segments); i++ {
+		segment := segments[i]
+
+		switch attr.Type {
+		case schema.TypeMap:
+			return path.IndexString(segment)
+		case schema.TypeList:
+			index, err := strconv.Atoi(segment)
+			if err != nil {
+				if attr.MaxItems != 1 {
+					return path
+				}
+				// Single nested blocks are sent to the API as objects rather than lists
+				index = 0
+				i--
+			}
+			path = path.IndexInt(index)
+
+			elem, ok := attr.Elem.(*schema.Resource)
+			if !ok || i+1 >= len(segments) {
+				return path
+			}
+			i++
+			nested, ok := elem.Schema[segments[i]]
+			if !ok {
+				return path
+			}
+			path = path.GetAttr(segments[i])
+			attr = nested
+		default:
+			return path
+		}
+	}
+
+	return path
+}
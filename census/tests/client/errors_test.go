@@ -0,0 +1,189 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// requestError returns the error from a GetWorkspace call against a server replying with status and body
+func requestError(t *testing.T, status int, body string) error {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	_, err := newRetryTestClient(t, server, 0).GetWorkspace(context.Background(), 42)
+	if err == nil {
+		t.Fatalf("GetWorkspace() expected error for status %d, got nil", status)
+	}
+	return err
+}
+
+func TestAPIError_Sentinels(t *testing.T) {
+	sentinels := []error{client.ErrNotFound, client.ErrUnauthorized, client.ErrForbidden, client.ErrRateLimited, client.ErrConflict}
+
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, client.ErrNotFound},
+		{http.StatusUnauthorized, client.ErrUnauthorized},
+		{http.StatusForbidden, client.ErrForbidden},
+		{http.StatusTooManyRequests, client.ErrRateLimited},
+		{http.StatusConflict, client.ErrConflict},
+		{http.StatusInternalServerError, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			err := requestError(t, tt.status, `{"status": "error", "message": "nope"}`)
+
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v, want %v", err, sentinel, got, sentinel == tt.want)
+				}
+			}
+
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("errors.As(%v, *APIError) = false, want true", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("APIError.StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestAPIError_ParsesBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantMessage string
+		wantStatus  string
+	}{
+		{
+			name:        "error envelope",
+			body:        `{"status": "error", "message": "Sync not found"}`,
+			wantMessage: "Sync not found",
+			wantStatus:  "error",
+		},
+		{
+			name:        "error member",
+			body:        `{"error": "Not found"}`,
+			wantMessage: "Not found",
+		},
+		{
+			name:        "plain text",
+			body:        "Not Found\n",
+			wantMessage: "Not Found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := requestError(t, http.StatusNotFound, tt.body)
+
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("errors.As(%v, *APIError) = false, want true", err)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("APIError.Message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			if apiErr.Status != tt.wantStatus {
+				t.Errorf("APIError.Status = %q, want %q", apiErr.Status, tt.wantStatus)
+			}
+			if apiErr.Body != tt.body {
+				t.Errorf("APIError.Body = %q, want the raw body %q", apiErr.Body, tt.body)
+			}
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantFields map[string][]string
+	}{
+		{
+			name:   "field map",
+			status: http.StatusUnprocessableEntity,
+			body:   `{"status": "error", "message": "Validation failed", "errors": {"label": ["can't be blank"], "paused": "is invalid"}}`,
+			wantFields: map[string][]string{
+				"label":  {"can't be blank"},
+				"paused": {"is invalid"},
+			},
+		},
+		{
+			name:   "nested field map",
+			status: http.StatusBadRequest,
+			body:   `{"errors": {"connection": {"credentials": {"password": ["is required"]}}}}`,
+			wantFields: map[string][]string{
+				"connection.credentials.password": {"is required"},
+			},
+		},
+		{
+			name:   "field list",
+			status: http.StatusUnprocessableEntity,
+			body:   `{"errors": [{"field": "name", "message": "is taken"}, {"attribute": "name", "messages": ["is too long"]}, "Something else"]}`,
+			wantFields: map[string][]string{
+				"name": {"is taken", "is too long"},
+			},
+		},
+		{
+			name:       "unprocessable without fields",
+			status:     http.StatusUnprocessableEntity,
+			body:       `{"status": "error", "message": "Invalid sync configuration"}`,
+			wantFields: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := requestError(t, tt.status, tt.body)
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("errors.As(%v, *ValidationError) = false, want true", err)
+			}
+			if len(tt.wantFields) > 0 && !reflect.DeepEqual(validationErr.FieldErrors, tt.wantFields) {
+				t.Errorf("ValidationError.FieldErrors = %v, want %v", validationErr.FieldErrors, tt.wantFields)
+			}
+			if len(tt.wantFields) == 0 && len(validationErr.FieldErrors) != 0 {
+				t.Errorf("ValidationError.FieldErrors = %v, want none", validationErr.FieldErrors)
+			}
+
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("ValidationError does not unwrap to an APIError with status %d", tt.status)
+			}
+		})
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := &client.ValidationError{
+		APIError: &client.APIError{StatusCode: 422, Message: "Validation failed"},
+		FieldErrors: map[string][]string{
+			"name":  {"is taken"},
+			"label": {"can't be blank", "is too short"},
+		},
+	}
+
+	want := "Census API error (status 422): Validation failed (label can't be blank, is too short; name is taken)"
+	if got := err.Error(); got != want {
+		t.Errorf("ValidationError.Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func TestLogging_StructuredFieldsAndMasking(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/7/api_key":
+			w.Write([]byte(`{"api_key": "workspace-secret-token"}`))
+		case "/sources":
+			w.Write([]byte(`{"status": "success", "data": {"id": 5, "name": "Warehouse"}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	apiClient := newRetryTestClient(t, server, 0)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = tflog.SetField(ctx, "workspace_id", "7")
+
+	token, err := apiClient.GetCachedWorkspaceAPIKey(ctx, 7)
+	if err != nil {
+		t.Fatalf("GetCachedWorkspaceAPIKey() unexpected error: %v", err)
+	}
+
+	_, err = apiClient.CreateSourceWithToken(ctx, &client.CreateSourceRequest{
+		Connection: client.SourceConnection{
+			Name: "Warehouse",
+			Type: "postgres",
+			Credentials: map[string]interface{}{
+				"host":     "db.example.com",
+				"password": "hunter2",
+			},
+		},
+	}, token)
+	if err != nil {
+		t.Fatalf("CreateSourceWithToken() unexpected error: %v", err)
+	}
+
+	raw := output.String()
+	for _, secret := range []string{"test-token", "workspace-secret-token", "hunter2"} {
+		if strings.Contains(raw, secret) {
+			t.Errorf("log output contains secret %q:\n%s", secret, raw)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Failed to decode log output: %v", err)
+	}
+
+	var sawCreate bool
+	for _, entry := range entries {
+		if entry["@module"] != "provider."+client.LogSubsystem {
+			t.Errorf("log entry from module %v, want provider.%s", entry["@module"], client.LogSubsystem)
+		}
+		if entry["workspace_id"] != "7" {
+			t.Errorf("log entry %q is missing the workspace_id root field", entry["@message"])
+		}
+		if entry["http.method"] == http.MethodPost && entry["http.path"] == "/sources" {
+			sawCreate = true
+		}
+	}
+	if !sawCreate {
+		t.Errorf("no log entry with http.method and http.path for the create source request:\n%s", raw)
+	}
+}
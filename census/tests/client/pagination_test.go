@@ -0,0 +1,223 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// newPaginatedServer serves totalPages pages of syncs with one record per page, numbered by page
+func newPaginatedServer(t *testing.T, totalPages int, requests *int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			t.Errorf("request without a valid page parameter: %s", r.URL.String())
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		next := "null"
+		if page < totalPages {
+			next = strconv.Itoa(page + 1)
+		}
+		fmt.Fprintf(w, `{
+			"status": "success",
+			"data": [{"id": %d, "label": "Sync %d"}],
+			"pagination": {"page": %d, "per_page": 1, "next_page": %s, "last_page": %d, "total_records": %d}
+		}`, page, page, page, next, totalPages, totalPages)
+	}))
+}
+
+func TestListAllSyncs(t *testing.T) {
+	for _, concurrency := range []int{0, 3} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			var requests int32
+			server := newPaginatedServer(t, 5, &requests)
+			defer server.Close()
+
+			syncs, err := newRetryTestClient(t, server, 0).ListAllSyncsWithToken(context.Background(), &client.ListAllOptions{
+				PerPage:     1,
+				Concurrency: concurrency,
+			}, "workspace-token")
+			if err != nil {
+				t.Fatalf("ListAllSyncsWithToken() unexpected error: %v", err)
+			}
+
+			if len(syncs) != 5 {
+				t.Fatalf("ListAllSyncsWithToken() returned %d syncs, want 5", len(syncs))
+			}
+			for i, sync := range syncs {
+				if sync.ID != i+1 {
+					t.Errorf("syncs[%d].ID = %d, want %d (results must stay in page order)", i, sync.ID, i+1)
+				}
+			}
+			if got := atomic.LoadInt32(&requests); got != 5 {
+				t.Errorf("server received %d requests, want 5", got)
+			}
+		})
+	}
+}
+
+func TestListAllPages_SinglePage(t *testing.T) {
+	var requests int32
+	server := newPaginatedServer(t, 1, &requests)
+	defer server.Close()
+
+	sources, err := newRetryTestClient(t, server, 0).ListAllSourcesWithToken(context.Background(), nil, "workspace-token")
+	if err != nil {
+		t.Fatalf("ListAllSourcesWithToken() unexpected error: %v", err)
+	}
+	if len(sources) != 1 {
+		t.Errorf("ListAllSourcesWithToken() returned %d sources, want 1", len(sources))
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestListAllPages_ConcurrencyLimit(t *testing.T) {
+	const limit = 2
+
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+	fetch := func(ctx context.Context, opts *client.ListOptions) ([]int, *client.PaginationInfo, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		mu.Lock()
+		if current > maxInFlight {
+			maxInFlight = current
+		}
+		mu.Unlock()
+
+		next := opts.Page + 1
+		return []int{opts.Page}, &client.PaginationInfo{Page: opts.Page, NextPage: &next, LastPage: 10}, nil
+	}
+
+	items, err := client.ListAllPages(context.Background(), &client.ListAllOptions{Concurrency: limit}, fetch)
+	if err != nil {
+		t.Fatalf("ListAllPages() unexpected error: %v", err)
+	}
+	if len(items) != 10 {
+		t.Errorf("ListAllPages() returned %d items, want 10", len(items))
+	}
+	if maxInFlight > limit {
+		t.Errorf("ListAllPages() ran %d requests at once, want at most %d", maxInFlight, limit)
+	}
+}
+
+func TestListAllPages_StopsOnError(t *testing.T) {
+	for _, concurrency := range []int{0, 4} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			pageErr := errors.New("boom")
+			fetch := func(ctx context.Context, opts *client.ListOptions) ([]int, *client.PaginationInfo, error) {
+				if opts.Page == 3 {
+					return nil, nil, pageErr
+				}
+				next := opts.Page + 1
+				return []int{opts.Page}, &client.PaginationInfo{Page: opts.Page, NextPage: &next, LastPage: 6}, nil
+			}
+
+			_, err := client.ListAllPages(context.Background(), &client.ListAllOptions{Concurrency: concurrency}, fetch)
+			if !errors.Is(err, pageErr) {
+				t.Errorf("ListAllPages() error = %v, want it to wrap the page error", err)
+			}
+		})
+	}
+}
+
+func TestListAllPages_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var fetched int32
+	fetch := func(ctx context.Context, opts *client.ListOptions) ([]int, *client.PaginationInfo, error) {
+		if atomic.AddInt32(&fetched, 1) == 2 {
+			cancel()
+		}
+		next := opts.Page + 1
+		return []int{opts.Page}, &client.PaginationInfo{Page: opts.Page, NextPage: &next}, nil
+	}
+
+	_, err := client.ListAllPages(ctx, nil, fetch)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ListAllPages() error = %v, want context.Canceled", err)
+	}
+	if got := atomic.LoadInt32(&fetched); got != 2 {
+		t.Errorf("fetched %d pages, want to stop after the page that cancelled the context", got)
+	}
+}
+
+func TestListAllPages_DetectsLoops(t *testing.T) {
+	fetch := func(ctx context.Context, opts *client.ListOptions) ([]int, *client.PaginationInfo, error) {
+		same := opts.Page
+		return []int{opts.Page}, &client.PaginationInfo{Page: opts.Page, NextPage: &same}, nil
+	}
+
+	if _, err := client.ListAllPages(context.Background(), nil, fetch); err == nil {
+		t.Error("ListAllPages() expected an error when next_page does not advance, got nil")
+	}
+}
+
+func TestListAllDatasets_KeepsSQLFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/datasets" {
+			t.Errorf("Expected path /datasets, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("type"); got != "sql" {
+			t.Errorf("Expected type=sql, got %q", got)
+		}
+
+		page := r.URL.Query().Get("page")
+		next := "2"
+		if page == "2" {
+			next = "null"
+		}
+		fmt.Fprintf(w, `{"status": "success", "data": [{"id": %s, "name": "Dataset %s"}], "pagination": {"page": %s, "next_page": %s, "last_page": 2}}`, page, page, page, next)
+	}))
+	defer server.Close()
+
+	datasets, err := newRetryTestClient(t, server, 0).ListDatasetsWithToken(context.Background(), "workspace-token")
+	if err != nil {
+		t.Fatalf("ListDatasetsWithToken() unexpected error: %v", err)
+	}
+	if len(datasets) != 2 {
+		t.Errorf("ListDatasetsWithToken() returned %d datasets, want 2", len(datasets))
+	}
+}
+
+func TestListWorkspaces_UsesBaseURLOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces" {
+			t.Errorf("Expected path /workspaces, got %s", r.URL.Path)
+		}
+		if strings.Contains(r.URL.String(), "http") {
+			t.Errorf("request URL %q contains the base URL twice", r.URL.String())
+		}
+		if got := r.URL.Query().Get("per_page"); got != "25" {
+			t.Errorf("Expected per_page=25, got %q", got)
+		}
+		w.Write([]byte(`{"status": "success", "data": [{"id": 1, "name": "Workspace"}], "pagination": {"page": 1, "next_page": null, "last_page": 1}}`))
+	}))
+	defer server.Close()
+
+	workspaces, err := newRetryTestClient(t, server, 0).ListAllWorkspaces(context.Background(), &client.ListAllOptions{PerPage: 25})
+	if err != nil {
+		t.Fatalf("ListAllWorkspaces() unexpected error: %v", err)
+	}
+	if len(workspaces) != 1 {
+		t.Errorf("ListAllWorkspaces() returned %d workspaces, want 1", len(workspaces))
+	}
+}
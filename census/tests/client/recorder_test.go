@@ -0,0 +1,132 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/tests/fakeapi"
+	"github.com/sutrolabs/terraform-provider-census/census/tests/recorder"
+)
+
+// recordedSourceCalls makes the requests that are recorded and then replayed
+func recordedSourceCalls(t *testing.T, c *client.Client) (*client.Source, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	workspace, err := c.CreateWorkspace(ctx, &client.CreateWorkspaceRequest{Name: "Recorded", ReturnWorkspaceAPIKey: true})
+	if err != nil {
+		t.Fatalf("CreateWorkspace() error = %v", err)
+	}
+	workspaceToken, err := c.GetWorkspaceAPIKey(ctx, workspace.ID)
+	if err != nil {
+		t.Fatalf("GetWorkspaceAPIKey() error = %v", err)
+	}
+	source, err := c.CreateSourceWithToken(ctx, &client.CreateSourceRequest{Connection: client.SourceConnection{
+		Name: "Warehouse",
+		Type: "redshift",
+		Credentials: map[string]interface{}{
+			"hostname": os.Getenv("CENSUS_TEST_REDSHIFT_HOST"),
+			"port":     "5439",
+			"database": "dev",
+			"user":     "census",
+			"password": "hunter2",
+		},
+	}}, workspaceToken)
+	if err != nil {
+		t.Fatalf("CreateSourceWithToken() error = %v", err)
+	}
+	source, err = c.GetSourceWithToken(ctx, source.ID, workspaceToken)
+	if err != nil {
+		t.Fatalf("GetSourceWithToken() error = %v", err)
+	}
+	return source, workspaceToken
+}
+
+func TestRecorder_RecordsScrubbedCassetteAndReplaysOffline(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	t.Setenv("CENSUS_TEST_REDSHIFT_HOST", "warehouse.internal.example.com")
+	t.Setenv("CENSUS_TEST_REDSHIFT_PORT", "5439")
+	variables := []recorder.Variable{
+		{Name: "CENSUS_TEST_REDSHIFT_HOST", Secret: true},
+		{Name: "CENSUS_TEST_REDSHIFT_PORT"},
+	}
+
+	rec, err := recorder.New(cassette, recorder.ModeRecord, variables)
+	if err != nil {
+		t.Fatalf("New(record) error = %v", err)
+	}
+	c, err := client.NewClient(&client.Config{PersonalAccessToken: fakeapi.PersonalAccessToken, BaseURL: server.URL, HTTPClient: rec.Client()})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	recorded, workspaceToken := recordedSourceCalls(t, c)
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %v", err)
+	}
+	for _, secret := range []string{fakeapi.PersonalAccessToken, workspaceToken, "hunter2", "warehouse.internal.example.com"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+	if !strings.Contains(string(data), "REDACTED_CENSUS_TEST_REDSHIFT_HOST") {
+		t.Errorf("cassette does not contain the host placeholder:\n%s", data)
+	}
+
+	// Replay with the recorded variables against a base URL that cannot be reached
+	server.Close()
+	rec, err = recorder.New(cassette, recorder.ModeReplay, variables)
+	if err != nil {
+		t.Fatalf("New(replay) error = %v", err)
+	}
+	for name, value := range rec.Variables() {
+		t.Setenv(name, value)
+	}
+	c, err = client.NewClient(&client.Config{PersonalAccessToken: "any-token", BaseURL: server.URL, HTTPClient: rec.Client()})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	replayed, _ := recordedSourceCalls(t, c)
+
+	if replayed.ID != recorded.ID || replayed.Name != recorded.Name {
+		t.Errorf("replayed source = %d %q, want %d %q", replayed.ID, replayed.Name, recorded.ID, recorded.Name)
+	}
+	if got := replayed.Connection["hostname"]; got != "REDACTED_CENSUS_TEST_REDSHIFT_HOST" {
+		t.Errorf("replayed hostname = %v, want the placeholder the replay configures", got)
+	}
+	if got := os.Getenv("CENSUS_TEST_REDSHIFT_PORT"); got != "5439" {
+		t.Errorf("replayed CENSUS_TEST_REDSHIFT_PORT = %q, want the recorded 5439", got)
+	}
+
+	// Repeated requests get the last recorded response, and unrecorded ones fail
+	if _, err := c.GetSourceWithToken(context.Background(), recorded.ID, "any-token"); err != nil {
+		t.Errorf("repeated GetSourceWithToken() error = %v", err)
+	}
+	if _, err := c.GetSourceWithToken(context.Background(), recorded.ID+1, "any-token"); err == nil || !strings.Contains(err.Error(), "no interaction") {
+		t.Errorf("unrecorded GetSourceWithToken() error = %v, want no interaction", err)
+	}
+}
+
+func TestRecorder_ReplayWithoutCassette(t *testing.T) {
+	_, err := recorder.New(filepath.Join(t.TempDir(), "missing.json"), recorder.ModeReplay, nil)
+	if !errors.Is(err, recorder.ErrNoCassette) {
+		t.Errorf("New() error = %v, want ErrNoCassette", err)
+	}
+
+	t.Setenv(recorder.ModeEnvVar, "rewind")
+	if _, err := recorder.ModeFromEnv(); err == nil {
+		t.Error("ModeFromEnv() accepted an unknown mode")
+	}
+}
@@ -0,0 +1,279 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// newRetryTestClient creates a client against the test server with fast backoff
+func newRetryTestClient(t *testing.T, server *httptest.Server, maxRetries int) *client.Client {
+	t.Helper()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+		MaxRetries:          maxRetries,
+		RetryWaitMin:        time.Millisecond,
+		RetryMaxWait:        10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return apiClient
+}
+
+const workspaceResponse = `{"status": "success", "data": {"id": 42, "name": "Test Workspace"}}`
+
+func TestRetry_ServerErrorThenSuccess(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) < 3 {
+					w.WriteHeader(status)
+					return
+				}
+				w.Write([]byte(workspaceResponse))
+			}))
+			defer server.Close()
+
+			workspace, err := newRetryTestClient(t, server, 3).GetWorkspace(context.Background(), 42)
+			if err != nil {
+				t.Fatalf("GetWorkspace() unexpected error: %v", err)
+			}
+			if workspace.ID != 42 {
+				t.Errorf("GetWorkspace() ID = %d, want 42", workspace.ID)
+			}
+			if got := atomic.LoadInt32(&attempts); got != 3 {
+				t.Errorf("server received %d requests, want 3", got)
+			}
+		})
+	}
+}
+
+func TestRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"status": "error", "message": "maintenance"}`))
+	}))
+	defer server.Close()
+
+	_, err := newRetryTestClient(t, server, 2).GetWorkspace(context.Background(), 42)
+	if err == nil {
+		t.Fatal("GetWorkspace() expected error, got nil")
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GetWorkspace() error = %v, want APIError with status 503", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("server received %d requests, want 3 (1 attempt + 2 retries)", got)
+	}
+}
+
+func TestRetry_Disabled(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if _, err := newRetryTestClient(t, server, 0).GetWorkspace(context.Background(), 42); err == nil {
+		t.Fatal("GetWorkspace() expected error, got nil")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestRetry_NonIdempotentMethods(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantAttempts int32
+	}{
+		{
+			name:         "POST is not retried on server error",
+			status:       http.StatusServiceUnavailable,
+			wantAttempts: 1,
+		},
+		{
+			name:         "POST is retried when rate limited",
+			status:       http.StatusTooManyRequests,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Write([]byte(workspaceResponse))
+			}))
+			defer server.Close()
+
+			newRetryTestClient(t, server, 3).CreateWorkspace(context.Background(), &client.CreateWorkspaceRequest{Name: "Test Workspace"})
+
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("server received %d requests, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetry_ResendsRequestBody(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength == 0 {
+			t.Errorf("attempt %d was sent without a body", atomic.LoadInt32(&attempts)+1)
+		}
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(workspaceResponse))
+	}))
+	defer server.Close()
+
+	_, err := newRetryTestClient(t, server, 3).UpdateWorkspace(context.Background(), 42, &client.UpdateWorkspaceRequest{Name: "Renamed"})
+	if err != nil {
+		t.Fatalf("UpdateWorkspace() unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestRetry_HonoursRetryAfter(t *testing.T) {
+	var attempts int32
+	var firstAttempt, secondAttempt time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			firstAttempt = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		secondAttempt = time.Now()
+		w.Write([]byte(workspaceResponse))
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+		MaxRetries:          1,
+		RetryWaitMin:        time.Millisecond,
+		RetryMaxWait:        5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := apiClient.GetWorkspace(context.Background(), 42); err != nil {
+		t.Fatalf("GetWorkspace() unexpected error: %v", err)
+	}
+	if waited := secondAttempt.Sub(firstAttempt); waited < time.Second {
+		t.Errorf("retried after %v, want at least the 1s requested by Retry-After", waited)
+	}
+}
+
+func TestRetry_RetryAfterIsCappedByMaxWait(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(workspaceResponse))
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	if _, err := newRetryTestClient(t, server, 1).GetWorkspace(context.Background(), 42); err != nil {
+		t.Fatalf("GetWorkspace() unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request took %v, Retry-After should have been capped by RetryMaxWait", elapsed)
+	}
+}
+
+func TestRetry_ConnectionReset(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// Drop the connection without writing a response
+			hijacker, ok := w.(http.Hijacker)
+			if !ok {
+				t.Fatal("response writer does not support hijacking")
+			}
+			conn, _, err := hijacker.Hijack()
+			if err != nil {
+				t.Fatalf("Failed to hijack connection: %v", err)
+			}
+			conn.Close()
+			return
+		}
+		w.Write([]byte(workspaceResponse))
+	}))
+	defer server.Close()
+
+	if _, err := newRetryTestClient(t, server, 3).GetWorkspace(context.Background(), 42); err != nil {
+		t.Fatalf("GetWorkspace() unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestRetry_StopsOnContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+		MaxRetries:          5,
+		RetryMaxWait:        time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = apiClient.GetWorkspace(ctx, 42)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetWorkspace() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request took %v, expected to stop waiting when the context was cancelled", elapsed)
+	}
+}
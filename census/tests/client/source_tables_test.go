@@ -0,0 +1,52 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+func TestGetSourceTableColumns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sources/10/tables":
+			if r.URL.Query().Get("page") == "1" {
+				w.Write([]byte(`{"status": "success", "data": [
+					{"id": 1, "table_catalog": "ANALYTICS", "table_schema": "STAGING", "table_name": "USERS"}
+				], "pagination": {"page": 1, "next_page": 2, "last_page": 2}}`))
+				return
+			}
+			w.Write([]byte(`{"status": "success", "data": [
+				{"id": 2, "table_catalog": "ANALYTICS", "table_schema": "PUBLIC", "table_name": "USERS"}
+			], "pagination": {"page": 2, "next_page": null, "last_page": 2}}`))
+		case "/sources/10/tables/2/columns":
+			w.Write([]byte(`{"status": "success", "data": [
+				{"name": "EMAIL", "data_type": "VARCHAR"},
+				{"name": "UPDATED_AT", "data_type": "TIMESTAMP_NTZ"}
+			], "pagination": {"page": 1, "next_page": null, "last_page": 1}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient := newRetryTestClient(t, server, 0)
+
+	columns, err := apiClient.GetSourceTableColumnsWithToken(context.Background(), 10, "analytics", "public", "users", "workspace-token")
+	if err != nil {
+		t.Fatalf("GetSourceTableColumnsWithToken() unexpected error: %v", err)
+	}
+	if len(columns) != 2 || columns[1].Name != "UPDATED_AT" || columns[1].DataType != "TIMESTAMP_NTZ" {
+		t.Errorf("GetSourceTableColumnsWithToken() = %+v, want the columns of PUBLIC.USERS", columns)
+	}
+
+	_, err = apiClient.GetSourceTableColumnsWithToken(context.Background(), 10, "", "", "accounts", "workspace-token")
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("GetSourceTableColumnsWithToken() error = %v, want ErrNotFound for a missing table", err)
+	}
+}
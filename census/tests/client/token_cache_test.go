@@ -0,0 +1,173 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// newTokenCacheTestServer serves workspace API keys and counts how often they are requested.
+// Each key request returns a new key so that refetches can be detected.
+func newTokenCacheTestServer(t *testing.T, keyRequests *int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1/api_key", "/workspaces/2/api_key":
+			n := atomic.AddInt32(keyRequests, 1)
+			// Slow down key lookups so concurrent callers overlap
+			time.Sleep(20 * time.Millisecond)
+			fmt.Fprintf(w, `{"api_key": "workspace-key-%d"}`, n)
+		case "/syncs/1":
+			if r.Header.Get("Authorization") == "Bearer workspace-key-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"status": "unauthorized", "message": "invalid API key"}`))
+				return
+			}
+			w.Write([]byte(`{"status": "success", "data": {"id": 1, "label": "Test Sync"}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetCachedWorkspaceAPIKey_DeduplicatesConcurrentLookups(t *testing.T) {
+	var keyRequests int32
+	server := newTokenCacheTestServer(t, &keyRequests)
+	defer server.Close()
+
+	apiClient := newRetryTestClient(t, server, 0)
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 20)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := apiClient.GetCachedWorkspaceAPIKey(context.Background(), 1)
+			if err != nil {
+				t.Errorf("GetCachedWorkspaceAPIKey() unexpected error: %v", err)
+			}
+			tokens[i] = token
+		}(i)
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&keyRequests); got != 1 {
+		t.Errorf("API key was requested %d times, want 1", got)
+	}
+	for i, token := range tokens {
+		if token != "workspace-key-1" {
+			t.Errorf("caller %d got token %q, want %q", i, token, "workspace-key-1")
+		}
+	}
+
+	// Subsequent calls are served from the cache
+	if _, err := apiClient.GetCachedWorkspaceAPIKey(context.Background(), 1); err != nil {
+		t.Fatalf("GetCachedWorkspaceAPIKey() unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&keyRequests); got != 1 {
+		t.Errorf("API key was requested %d times after a cached lookup, want 1", got)
+	}
+}
+
+func TestGetCachedWorkspaceAPIKey_PerWorkspace(t *testing.T) {
+	var keyRequests int32
+	server := newTokenCacheTestServer(t, &keyRequests)
+	defer server.Close()
+
+	apiClient := newRetryTestClient(t, server, 0)
+
+	first, _ := apiClient.GetCachedWorkspaceAPIKey(context.Background(), 1)
+	second, _ := apiClient.GetCachedWorkspaceAPIKey(context.Background(), 2)
+
+	if first == second {
+		t.Errorf("workspaces 1 and 2 share token %q, want separate cache entries", first)
+	}
+	if got := atomic.LoadInt32(&keyRequests); got != 2 {
+		t.Errorf("API key was requested %d times, want 2", got)
+	}
+}
+
+func TestGetCachedWorkspaceAPIKey_InvalidatedOnUnauthorized(t *testing.T) {
+	var keyRequests int32
+	server := newTokenCacheTestServer(t, &keyRequests)
+	defer server.Close()
+
+	apiClient := newRetryTestClient(t, server, 0)
+
+	token, err := apiClient.GetCachedWorkspaceAPIKey(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetCachedWorkspaceAPIKey() unexpected error: %v", err)
+	}
+
+	// The first key is rejected by the server
+	if _, err := apiClient.GetSyncWithToken(context.Background(), 1, token); err == nil {
+		t.Fatal("GetSyncWithToken() expected 401 error, got nil")
+	}
+
+	token, err = apiClient.GetCachedWorkspaceAPIKey(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetCachedWorkspaceAPIKey() unexpected error: %v", err)
+	}
+	if token != "workspace-key-2" {
+		t.Errorf("GetCachedWorkspaceAPIKey() = %q after a 401, want a freshly fetched key", token)
+	}
+
+	if _, err := apiClient.GetSyncWithToken(context.Background(), 1, token); err != nil {
+		t.Errorf("GetSyncWithToken() with refreshed key unexpected error: %v", err)
+	}
+}
+
+func TestInvalidateWorkspaceAPIKey(t *testing.T) {
+	var keyRequests int32
+	server := newTokenCacheTestServer(t, &keyRequests)
+	defer server.Close()
+
+	apiClient := newRetryTestClient(t, server, 0)
+
+	apiClient.GetCachedWorkspaceAPIKey(context.Background(), 1)
+	apiClient.InvalidateWorkspaceAPIKey(1)
+	apiClient.GetCachedWorkspaceAPIKey(context.Background(), 1)
+
+	if got := atomic.LoadInt32(&keyRequests); got != 2 {
+		t.Errorf("API key was requested %d times, want 2", got)
+	}
+}
+
+func TestGetCachedWorkspaceAPIKey_ErrorsAreNotCached(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"api_key": "workspace-key"}`))
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := apiClient.GetCachedWorkspaceAPIKey(context.Background(), 1); err == nil {
+		t.Fatal("GetCachedWorkspaceAPIKey() expected error, got nil")
+	}
+	token, err := apiClient.GetCachedWorkspaceAPIKey(context.Background(), 1)
+	if err != nil || token != "workspace-key" {
+		t.Errorf("GetCachedWorkspaceAPIKey() = %q, %v, want the key after a failed lookup", token, err)
+	}
+}
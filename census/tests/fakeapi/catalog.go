@@ -0,0 +1,149 @@
+package fakeapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// sourceTypes is the /source_types catalog served to every workspace
+var sourceTypes = []map[string]interface{}{
+	warehouseSourceType("redshift", "Redshift"),
+	warehouseSourceType("postgres", "Postgres"),
+}
+
+func warehouseSourceType(serviceName, label string) map[string]interface{} {
+	return map[string]interface{}{
+		"documentation_slug":     serviceName,
+		"label":                  label,
+		"service_name":           serviceName,
+		"supported_sync_engines": []string{"basic", "advanced"},
+		"creatable_via_api":      true,
+		"editable_via_api":       true,
+		"configuration_fields": map[string]interface{}{
+			"fields": []map[string]interface{}{
+				{"id": "hostname", "label": "Hostname", "type": "string", "rules": []string{"required"}},
+				{"id": "port", "label": "Port", "type": "integer", "rules": []string{"required"}},
+				{"id": "database", "label": "Database", "type": "string", "rules": []string{"required"}},
+				{"id": "user", "label": "User", "type": "string", "rules": []string{"required"}},
+				{"id": "password", "label": "Password", "type": "string", "rules": []string{"required"}, "is_password_type_field": true},
+			},
+		},
+	}
+}
+
+// connectors is the /connectors catalog served to every workspace
+var connectors = []map[string]interface{}{
+	{
+		"documentation_slug": "salesforce",
+		"label":              "Salesforce",
+		"service_name":       "salesforce",
+		"supports_test":      true,
+		"creatable_via_api":  true,
+		"configuration_fields": map[string]interface{}{
+			"fields": []map[string]interface{}{
+				{"id": "username", "label": "Username", "type": "string", "rules": []string{"required"}},
+				{"id": "instance_url", "label": "Instance URL", "type": "string", "rules": []string{"required"}},
+				{"id": "client_id", "label": "Client ID", "type": "string", "rules": []string{"required"}},
+				{"id": "jwt_signing_key", "label": "JWT Signing Key", "type": "string", "rules": []string{"required"}, "is_password_type_field": true},
+				{"id": "domain", "label": "Domain", "type": "string"},
+			},
+		},
+	},
+}
+
+// sourceTables are the tables every source reports, with their columns
+var sourceTables = []struct {
+	catalog, schema, name string
+	columns               []map[string]interface{}
+}{
+	{
+		catalog: "dev",
+		schema:  "public",
+		name:    "users",
+		columns: []map[string]interface{}{
+			{"name": "id", "data_type": "integer"},
+			{"name": "email", "data_type": "varchar"},
+			{"name": "first_name", "data_type": "varchar"},
+			{"name": "last_name", "data_type": "varchar"},
+			{"name": "active", "data_type": "boolean"},
+			{"name": "updated_at", "data_type": "timestamp"},
+		},
+	},
+}
+
+// destinationObjects returns the objects a new destination of a connector type reports
+func destinationObjects(serviceName string) []map[string]interface{} {
+	if serviceName != "salesforce" {
+		return nil
+	}
+	field := func(id, fieldType string, required bool) map[string]interface{} {
+		return map[string]interface{}{"id": id, "name": id, "type": fieldType, "required": required}
+	}
+	return []map[string]interface{}{
+		{
+			"id": "Contact", "name": "Contact", "type": "object", "full_name": "Contact",
+			"fields": []map[string]interface{}{
+				field("Email", "email", false),
+				field("FirstName", "string", false),
+				field("LastName", "string", true),
+				field("LeadSource", "picklist", false),
+				field("Census_ID__c", "string", false),
+			},
+		},
+		{
+			"id": "Lead", "name": "Lead", "type": "object", "full_name": "Lead",
+			"fields": []map[string]interface{}{
+				field("Email", "email", false),
+				field("LastName", "string", true),
+				field("Company", "string", true),
+			},
+		},
+	}
+}
+
+// passwordFields returns the fields of a catalog entry that are never returned by the API
+func passwordFields(entry map[string]interface{}) map[string]bool {
+	secret := make(map[string]bool)
+	fields := entry["configuration_fields"].(map[string]interface{})["fields"].([]map[string]interface{})
+	for _, field := range fields {
+		if isPassword, _ := field["is_password_type_field"].(bool); isPassword {
+			secret[field["id"].(string)] = true
+		}
+	}
+	return secret
+}
+
+// findCatalogEntry returns the source type or connector with a service name, or nil
+func findCatalogEntry(catalog []map[string]interface{}, serviceName string) map[string]interface{} {
+	for _, entry := range catalog {
+		if entry["service_name"] == serviceName {
+			return entry
+		}
+	}
+	return nil
+}
+
+var selectListPattern = regexp.MustCompile(`(?is)^\s*select\s+(.*?)\s+from\s`)
+
+// queryColumns returns the columns a dataset query selects when its select list is a plain list
+// of columns or aliased expressions, or nil when it cannot tell
+func queryColumns(query string) []map[string]interface{} {
+	match := selectListPattern.FindStringSubmatch(query)
+	if match == nil {
+		return nil
+	}
+
+	var columns []map[string]interface{}
+	for _, expression := range strings.Split(match[1], ",") {
+		fields := strings.Fields(expression)
+		if len(fields) == 0 || strings.Contains(expression, "*") {
+			return nil
+		}
+		name := fields[len(fields)-1]
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		columns = append(columns, map[string]interface{}{"name": strings.Trim(name, `"`), "data_type": "varchar"})
+	}
+	return columns
+}
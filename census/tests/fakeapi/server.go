@@ -0,0 +1,913 @@
+// Package fakeapi is an in-process fake of the Census Management API. It keeps workspaces, sources,
+// destinations, datasets, syncs and sync runs in memory and answers the requests the client makes,
+// so the provider's resource lifecycle can be tested offline by pointing base_url at Server.URL.
+package fakeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PersonalAccessToken is the only personal access token the fake accepts
+const PersonalAccessToken = "fake-personal-access-token"
+
+// defaultPerPage is the page size of list endpoints when the request does not set per_page
+const defaultPerPage = 25
+
+// Collections of workspace-scoped records, named after their API paths
+const (
+	sources      = "sources"
+	destinations = "destinations"
+	datasets     = "datasets"
+	syncs        = "syncs"
+	syncRuns     = "sync_runs"
+)
+
+// Request is a request the server received, with its JSON body decoded when it has one
+type Request struct {
+	Method string
+	Path   string
+	Body   map[string]interface{}
+}
+
+// record is a stored API object and the workspace it belongs to
+type record struct {
+	workspaceID int
+	data        map[string]interface{}
+}
+
+// Server is a fake Census Management API. Every request authenticates with PersonalAccessToken
+// or with the API key of a workspace, as the real API does. IDs are assigned in order and
+// timestamps come from a clock that advances one second per write, so runs are deterministic.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	nextID     int
+	now        time.Time
+	workspaces map[int]*record
+	apiKeys    map[string]int
+	records    map[string]map[int]*record
+
+	// destination ID -> objects reported by the destination
+	objects map[int][]map[string]interface{}
+
+	requests []Request
+}
+
+// New starts a fake API server with no workspaces. Call Close when done.
+func New() *Server {
+	s := &Server{
+		now:        time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		workspaces: make(map[int]*record),
+		apiKeys:    make(map[string]int),
+		records:    make(map[string]map[int]*record),
+		objects:    make(map[int][]map[string]interface{}),
+	}
+	for _, collection := range []string{sources, destinations, datasets, syncs, syncRuns} {
+		s.records[collection] = make(map[int]*record)
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// CreateWorkspace adds a workspace directly, for tests that need one without going through the
+// provider, and returns its ID and API key
+func (s *Server) CreateWorkspace(name string) (int, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ws := s.createWorkspace(name, nil)
+	return ws.data["id"].(int), ws.data["api_key"].(string)
+}
+
+// Env returns the environment that points the provider and the acceptance tests at the server:
+// its base_url, the personal access token it accepts and placeholder warehouse and CRM credentials
+func (s *Server) Env() map[string]string {
+	return map[string]string{
+		"CENSUS_BASE_URL":                        s.URL,
+		"CENSUS_PERSONAL_ACCESS_TOKEN":           PersonalAccessToken,
+		"CENSUS_TEST_REDSHIFT_HOST":              "redshift.fake.test",
+		"CENSUS_TEST_REDSHIFT_PORT":              "5439",
+		"CENSUS_TEST_REDSHIFT_DATABASE":          "dev",
+		"CENSUS_TEST_REDSHIFT_USERNAME":          "census",
+		"CENSUS_TEST_REDSHIFT_PASSWORD":          "fake-redshift-password",
+		"CENSUS_TEST_SALESFORCE_USERNAME":        "census@fake.test",
+		"CENSUS_TEST_SALESFORCE_INSTANCE_URL":    "https://salesforce.fake.test",
+		"CENSUS_TEST_SALESFORCE_CLIENT_ID":       "fake-client-id",
+		"CENSUS_TEST_SALESFORCE_JWT_SIGNING_KEY": "fake-jwt-signing-key",
+		"CENSUS_TEST_SALESFORCE_DOMAIN":          "test.salesforce.com",
+	}
+}
+
+// Count returns the number of stored objects in a collection, such as "syncs", or "workspaces"
+func (s *Server) Count(collection string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if collection == "workspaces" {
+		return len(s.workspaces)
+	}
+	return len(s.records[collection])
+}
+
+// Requests returns the requests received so far with the given method, such as "PATCH", in order
+func (s *Server) Requests(method string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []Request
+	for _, request := range s.requests {
+		if request.Method == method {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.recordRequest(r)
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	if path[0] == "workspaces" {
+		if token != PersonalAccessToken {
+			writeError(w, http.StatusUnauthorized, "a personal access token is required")
+			return
+		}
+		s.serveWorkspaces(w, r, path[1:])
+		return
+	}
+
+	workspaceID, ok := s.apiKeys[token]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "a workspace API key is required")
+		return
+	}
+
+	switch path[0] {
+	case "workspace":
+		writeData(w, http.StatusOK, s.workspaceData(s.workspaces[workspaceID], false))
+	case "source_types":
+		writeData(w, http.StatusOK, sourceTypes)
+	case "connectors":
+		writePage(w, r, toInterfaces(connectors))
+	case sources:
+		s.serveSources(w, r, workspaceID, path[1:])
+	case destinations:
+		s.serveDestinations(w, r, workspaceID, path[1:])
+	case datasets:
+		s.serveDatasets(w, r, workspaceID, path[1:])
+	case syncs:
+		s.serveSyncs(w, r, workspaceID, path[1:])
+	case syncRuns:
+		s.serveSyncRuns(w, r, workspaceID, path[1:])
+	default:
+		writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+	}
+}
+
+// recordRequest adds r to the request log, leaving its body to be read again by the handler
+func (s *Server) recordRequest(r *http.Request) {
+	request := Request{Method: r.Method, Path: r.URL.Path}
+	if r.Body != nil {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if len(body) > 0 {
+			json.Unmarshal(body, &request.Body)
+		}
+	}
+	s.requests = append(s.requests, request)
+}
+
+func (s *Server) serveWorkspaces(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			ids := sortedIDs(s.workspaces)
+			items := make([]interface{}, 0, len(ids))
+			for _, id := range ids {
+				items = append(items, s.workspaceData(s.workspaces[id], false))
+			}
+			writePage(w, r, items)
+		case http.MethodPost:
+			var body struct {
+				Name                  string   `json:"name"`
+				NotificationEmails    []string `json:"notification_emails"`
+				ReturnWorkspaceAPIKey bool     `json:"return_workspace_api_key"`
+			}
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			if body.Name == "" {
+				writeFieldError(w, "name", "can't be blank")
+				return
+			}
+			ws := s.createWorkspace(body.Name, body.NotificationEmails)
+			writeData(w, http.StatusCreated, s.workspaceData(ws, body.ReturnWorkspaceAPIKey))
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(path[0])
+	ws := s.workspaces[id]
+	if err != nil || ws == nil {
+		writeError(w, http.StatusNotFound, "workspace not found")
+		return
+	}
+
+	if len(path) == 2 && path[1] == "api_key" && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"api_key": ws.data["api_key"]})
+		return
+	}
+	if len(path) != 1 {
+		writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, s.workspaceData(ws, false))
+	case http.MethodPatch:
+		var body map[string]interface{}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if name, ok := body["name"].(string); ok && name != "" {
+			ws.data["name"] = name
+		}
+		if emails, ok := body["notification_emails"]; ok {
+			ws.data["notification_emails"] = emails
+		}
+		writeData(w, http.StatusOK, s.workspaceData(ws, false))
+	case http.MethodDelete:
+		for _, records := range s.records {
+			for recordID, rec := range records {
+				if rec.workspaceID == id {
+					delete(records, recordID)
+					delete(s.objects, recordID)
+				}
+			}
+		}
+		delete(s.apiKeys, ws.data["api_key"].(string))
+		delete(s.workspaces, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "deleted"})
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) createWorkspace(name string, notificationEmails []string) *record {
+	id := s.newID()
+	if notificationEmails == nil {
+		notificationEmails = []string{}
+	}
+	ws := &record{workspaceID: id, data: map[string]interface{}{
+		"id":                  id,
+		"name":                name,
+		"organization_id":     1,
+		"created_at":          s.tick(),
+		"notification_emails": notificationEmails,
+		"api_key":             fmt.Sprintf("fake-workspace-api-key-%d", id),
+	}}
+	s.workspaces[id] = ws
+	s.apiKeys[ws.data["api_key"].(string)] = id
+	return ws
+}
+
+// workspaceData returns a workspace as the API returns it, which only includes the API key when
+// it was asked for on creation
+func (s *Server) workspaceData(ws *record, withAPIKey bool) map[string]interface{} {
+	data := copyMap(ws.data)
+	if !withAPIKey {
+		delete(data, "api_key")
+	}
+	return data
+}
+
+func (s *Server) serveSources(w http.ResponseWriter, r *http.Request, workspaceID int, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writePage(w, r, s.list(sources, workspaceID))
+		case http.MethodPost:
+			var body struct {
+				Connection map[string]interface{} `json:"connection"`
+			}
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			sourceType, _ := body.Connection["type"].(string)
+			sourceTypeEntry := findCatalogEntry(sourceTypes, sourceType)
+			if sourceTypeEntry == nil {
+				writeFieldError(w, "connection.type", fmt.Sprintf("%q is not a supported source type", sourceType))
+				return
+			}
+			now := s.tick()
+			rec := s.create(sources, workspaceID, map[string]interface{}{
+				"name":        body.Connection["name"],
+				"type":        sourceType,
+				"connection":  publicCredentials(body.Connection["credentials"], passwordFields(sourceTypeEntry)),
+				"status":      "working",
+				"test_status": "succeeded",
+				"created_at":  now,
+				"updated_at":  now,
+			})
+			writeData(w, http.StatusCreated, rec.data)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	rec, ok := s.find(w, sources, workspaceID, path[0])
+	if !ok {
+		return
+	}
+
+	if len(path) > 1 {
+		s.serveSourceObjects(w, r, rec, path[1:])
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, rec.data)
+	case http.MethodPatch:
+		var body struct {
+			Connection map[string]interface{} `json:"connection"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if name, ok := body.Connection["name"].(string); ok && name != "" {
+			rec.data["name"] = name
+		}
+		if credentials, ok := body.Connection["credentials"].(map[string]interface{}); ok {
+			rec.data["connection"] = publicCredentials(credentials, passwordFields(findCatalogEntry(sourceTypes, rec.data["type"].(string))))
+		}
+		rec.data["updated_at"] = s.tick()
+		writeData(w, http.StatusOK, rec.data)
+	case http.MethodDelete:
+		s.delete(sources, rec)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "deleted"})
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// serveSourceObjects serves the tables, objects and refresh endpoints under /sources/{id}
+func (s *Server) serveSourceObjects(w http.ResponseWriter, r *http.Request, source *record, path []string) {
+	switch {
+	case path[0] == "objects" && r.Method == http.MethodGet:
+		items := make([]interface{}, 0, len(sourceTables))
+		for i, table := range sourceTables {
+			items = append(items, map[string]interface{}{
+				"id":        strconv.Itoa(i + 1),
+				"name":      table.name,
+				"type":      "table",
+				"full_name": table.catalog + "." + table.schema + "." + table.name,
+			})
+		}
+		writeData(w, http.StatusOK, items)
+	case path[0] == "tables" && len(path) == 1 && r.Method == http.MethodGet:
+		items := make([]interface{}, 0, len(sourceTables))
+		for i, table := range sourceTables {
+			items = append(items, map[string]interface{}{
+				"id":            i + 1,
+				"table_catalog": table.catalog,
+				"table_schema":  table.schema,
+				"table_name":    table.name,
+			})
+		}
+		writePage(w, r, items)
+	case path[0] == "tables" && len(path) == 3 && path[2] == "columns" && r.Method == http.MethodGet:
+		tableID, err := strconv.Atoi(path[1])
+		if err != nil || tableID < 1 || tableID > len(sourceTables) {
+			writeError(w, http.StatusNotFound, "table not found")
+			return
+		}
+		writePage(w, r, toInterfaces(sourceTables[tableID-1].columns))
+	case path[0] == "refresh_tables" && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "success", "data": map[string]interface{}{"refresh_key": source.data["id"]}})
+	case path[0] == "refresh_tables_status" && r.Method == http.MethodGet:
+		writeData(w, http.StatusOK, map[string]interface{}{"status": "completed", "in_progress": false})
+	case path[0] == "connect_links" && r.Method == http.MethodPost:
+		s.writeConnectLink(w, "sources", source)
+	default:
+		writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+	}
+}
+
+func (s *Server) serveDestinations(w http.ResponseWriter, r *http.Request, workspaceID int, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writePage(w, r, s.list(destinations, workspaceID))
+		case http.MethodPost:
+			var body struct {
+				Type              string                 `json:"type"`
+				Name              string                 `json:"name"`
+				ServiceConnection map[string]interface{} `json:"service_connection"`
+			}
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			connector := findCatalogEntry(connectors, body.Type)
+			if connector == nil {
+				writeFieldError(w, "type", fmt.Sprintf("%q is not a supported destination type", body.Type))
+				return
+			}
+			name := body.Name
+			if name == "" {
+				name, _ = body.ServiceConnection["name"].(string)
+			}
+			now := s.tick()
+			rec := s.create(destinations, workspaceID, map[string]interface{}{
+				"name":        name,
+				"type":        body.Type,
+				"connection":  publicCredentials(body.ServiceConnection["credentials"], passwordFields(connector)),
+				"status":      "working",
+				"test_status": "succeeded",
+				"created_at":  now,
+				"updated_at":  now,
+			})
+			s.objects[rec.data["id"].(int)] = destinationObjects(body.Type)
+			writeData(w, http.StatusCreated, rec.data)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	rec, ok := s.find(w, destinations, workspaceID, path[0])
+	if !ok {
+		return
+	}
+
+	if len(path) > 1 {
+		s.serveDestinationObjects(w, r, rec, path[1:])
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, rec.data)
+	case http.MethodPatch:
+		var body struct {
+			Name              string                 `json:"name"`
+			ServiceConnection map[string]interface{} `json:"service_connection"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if body.Name != "" {
+			rec.data["name"] = body.Name
+		}
+		if credentials, ok := body.ServiceConnection["credentials"].(map[string]interface{}); ok {
+			rec.data["connection"] = publicCredentials(credentials, passwordFields(findCatalogEntry(connectors, rec.data["type"].(string))))
+		}
+		rec.data["updated_at"] = s.tick()
+		writeData(w, http.StatusOK, rec.data)
+	case http.MethodDelete:
+		s.delete(destinations, rec)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "deleted"})
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// serveDestinationObjects serves the objects and refresh endpoints under /destinations/{id}
+func (s *Server) serveDestinationObjects(w http.ResponseWriter, r *http.Request, destination *record, path []string) {
+	id := destination.data["id"].(int)
+
+	switch {
+	case path[0] == "objects" && r.Method == http.MethodGet:
+		writeData(w, http.StatusOK, toInterfaces(s.objects[id]))
+	case path[0] == "object_creation_requests" && r.Method == http.MethodPost:
+		var body struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		if body.Name == "" {
+			writeFieldError(w, "name", "can't be blank")
+			return
+		}
+		s.objects[id] = append(s.objects[id], map[string]interface{}{
+			"id": body.Name, "name": body.Name, "type": body.Type, "full_name": body.Name,
+		})
+		writeData(w, http.StatusCreated, map[string]interface{}{"id": body.Name, "status": "completed"})
+	case path[0] == "refresh_objects" && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "success", "data": map[string]interface{}{"refresh_key": id}})
+	case path[0] == "refresh_objects_status" && r.Method == http.MethodGet:
+		writeData(w, http.StatusOK, map[string]interface{}{"status": "completed", "in_progress": false})
+	case path[0] == "connect_links" && r.Method == http.MethodPost:
+		s.writeConnectLink(w, "destinations", destination)
+	default:
+		writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+	}
+}
+
+func (s *Server) serveDatasets(w http.ResponseWriter, r *http.Request, workspaceID int, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			items := s.list(datasets, workspaceID)
+			if datasetType := r.URL.Query().Get("type"); datasetType != "" {
+				filtered := items[:0]
+				for _, item := range items {
+					if item.(map[string]interface{})["type"] == datasetType {
+						filtered = append(filtered, item)
+					}
+				}
+				items = filtered
+			}
+			writePage(w, r, items)
+		case http.MethodPost:
+			var body map[string]interface{}
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			sourceID, _ := body["source_id"].(float64)
+			if source := s.records[sources][int(sourceID)]; source == nil || source.workspaceID != workspaceID {
+				writeFieldError(w, "source_id", "does not refer to a source in this workspace")
+				return
+			}
+			if query, _ := body["query"].(string); query == "" {
+				writeFieldError(w, "query", "can't be blank")
+				return
+			}
+			now := s.tick()
+			body["source_id"] = int(sourceID)
+			body["created_at"] = now
+			body["updated_at"] = now
+			rec := s.create(datasets, workspaceID, body)
+			rec.data["resource_identifier"] = fmt.Sprintf("dataset:%d", rec.data["id"])
+			setDatasetColumns(rec)
+			writeData(w, http.StatusCreated, rec.data)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	rec, ok := s.find(w, datasets, workspaceID, path[0])
+	if !ok {
+		return
+	}
+	if len(path) != 1 {
+		writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, rec.data)
+	case http.MethodPatch:
+		var body map[string]interface{}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		for _, key := range []string{"name", "description", "query"} {
+			if value, ok := body[key]; ok {
+				rec.data[key] = value
+			}
+		}
+		rec.data["updated_at"] = s.tick()
+		setDatasetColumns(rec)
+		writeData(w, http.StatusOK, rec.data)
+	case http.MethodDelete:
+		s.delete(datasets, rec)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "deleted"})
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+func setDatasetColumns(dataset *record) {
+	query, _ := dataset.data["query"].(string)
+	if columns := queryColumns(query); columns != nil {
+		dataset.data["columns"] = columns
+	} else {
+		delete(dataset.data, "columns")
+	}
+}
+
+func (s *Server) serveSyncs(w http.ResponseWriter, r *http.Request, workspaceID int, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writePage(w, r, s.list(syncs, workspaceID))
+		case http.MethodPost:
+			var body map[string]interface{}
+			if !decodeBody(w, r, &body) {
+				return
+			}
+			for _, required := range []string{"operation", "source_attributes", "destination_attributes"} {
+				if isBlank(body[required]) {
+					writeFieldError(w, required, "can't be blank")
+					return
+				}
+			}
+			now := s.tick()
+			body["created_at"] = now
+			body["updated_at"] = now
+			body["status"] = "Ready"
+			if _, ok := body["paused"]; !ok {
+				body["paused"] = false
+			}
+			rec := s.create(syncs, workspaceID, body)
+			s.assignAlertIDs(rec.data)
+			writeData(w, http.StatusCreated, map[string]interface{}{"sync_id": rec.data["id"]})
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+		return
+	}
+
+	rec, ok := s.find(w, syncs, workspaceID, path[0])
+	if !ok {
+		return
+	}
+
+	if len(path) == 2 && path[1] == "trigger" && r.Method == http.MethodPost {
+		now := s.tick()
+		run := s.create(syncRuns, workspaceID, map[string]interface{}{
+			"sync_id":           rec.data["id"],
+			"status":            "completed",
+			"created_at":        now,
+			"started_at":        now,
+			"completed_at":      now,
+			"records_processed": 0,
+			"records_succeeded": 0,
+			"records_failed":    0,
+		})
+		rec.data["last_run_at"] = now
+		rec.data["last_run_id"] = run.data["id"]
+		writeData(w, http.StatusCreated, map[string]interface{}{"sync_run_id": run.data["id"]})
+		return
+	}
+	if len(path) != 1 {
+		writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, rec.data)
+	case http.MethodPatch:
+		var body map[string]interface{}
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		for key, value := range body {
+			rec.data[key] = value
+		}
+		rec.data["updated_at"] = s.tick()
+		s.assignAlertIDs(rec.data)
+		writeData(w, http.StatusOK, rec.data)
+	case http.MethodDelete:
+		s.delete(syncs, rec)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "deleted"})
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// assignAlertIDs gives new alert_attributes of a sync an ID, as the API does when it stores them
+func (s *Server) assignAlertIDs(sync map[string]interface{}) {
+	alerts, _ := sync["alert_attributes"].([]interface{})
+	for _, alert := range alerts {
+		if a, ok := alert.(map[string]interface{}); ok && isBlank(a["id"]) {
+			a["id"] = s.newID()
+		}
+	}
+}
+
+func (s *Server) serveSyncRuns(w http.ResponseWriter, r *http.Request, workspaceID int, path []string) {
+	if len(path) == 0 {
+		writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+		return
+	}
+	rec, ok := s.find(w, syncRuns, workspaceID, path[0])
+	if !ok {
+		return
+	}
+
+	switch {
+	case len(path) == 1 && r.Method == http.MethodGet:
+		writeData(w, http.StatusOK, rec.data)
+	case len(path) == 2 && path[1] == "cancel" && r.Method == http.MethodPost:
+		if rec.data["status"] == "working" {
+			rec.data["status"] = "cancelled"
+			rec.data["completed_at"] = s.tick()
+		}
+		writeData(w, http.StatusOK, rec.data)
+	default:
+		writeError(w, http.StatusNotFound, "no route for "+r.URL.Path)
+	}
+}
+
+func (s *Server) writeConnectLink(w http.ResponseWriter, collection string, rec *record) {
+	writeData(w, http.StatusCreated, map[string]interface{}{
+		"url":        fmt.Sprintf("%s/connect/%s/%d", s.URL, collection, rec.data["id"]),
+		"expires_at": s.now.Add(time.Hour),
+	})
+}
+
+// create stores a new record in a collection and assigns it the next ID
+func (s *Server) create(collection string, workspaceID int, data map[string]interface{}) *record {
+	id := s.newID()
+	data["id"] = id
+	rec := &record{workspaceID: workspaceID, data: data}
+	s.records[collection][id] = rec
+	return rec
+}
+
+// find looks up a record of the workspace by the ID in the path, writing a 404 when there is none
+func (s *Server) find(w http.ResponseWriter, collection string, workspaceID int, rawID string) (*record, bool) {
+	id, err := strconv.Atoi(rawID)
+	rec := s.records[collection][id]
+	if err != nil || rec == nil || rec.workspaceID != workspaceID {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", strings.TrimSuffix(collection, "s"), rawID))
+		return nil, false
+	}
+	return rec, true
+}
+
+func (s *Server) delete(collection string, rec *record) {
+	id := rec.data["id"].(int)
+	delete(s.records[collection], id)
+	delete(s.objects, id)
+}
+
+// list returns the records of a workspace in a collection, ordered by ID
+func (s *Server) list(collection string, workspaceID int) []interface{} {
+	var items []interface{}
+	for _, id := range sortedIDs(s.records[collection]) {
+		if rec := s.records[collection][id]; rec.workspaceID == workspaceID {
+			items = append(items, rec.data)
+		}
+	}
+	return items
+}
+
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+// tick advances the fake clock and returns the new time
+func (s *Server) tick() time.Time {
+	s.now = s.now.Add(time.Second)
+	return s.now
+}
+
+// publicCredentials returns the credentials of a connection without its password fields, which
+// the API never returns
+func publicCredentials(credentials interface{}, secret map[string]bool) map[string]interface{} {
+	public := make(map[string]interface{})
+	values, _ := credentials.(map[string]interface{})
+	for key, value := range values {
+		if !secret[key] {
+			public[key] = value
+		}
+	}
+	return public
+}
+
+func sortedIDs(records map[int]*record) []int {
+	ids := make([]int, 0, len(records))
+	for id := range records {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		copied[key] = value
+	}
+	return copied
+}
+
+func toInterfaces(items []map[string]interface{}) []interface{} {
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		result = append(result, item)
+	}
+	return result
+}
+
+func isBlank(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, body interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writePage writes one page of items with the pagination block of the API's list endpoints
+func writePage(w http.ResponseWriter, r *http.Request, items []interface{}) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+
+	lastPage := (len(items) + perPage - 1) / perPage
+	if lastPage < 1 {
+		lastPage = 1
+	}
+	start, end := (page-1)*perPage, page*perPage
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	pagination := map[string]interface{}{
+		"total_records": len(items),
+		"per_page":      perPage,
+		"page":          page,
+		"last_page":     lastPage,
+		"prev_page":     nil,
+		"next_page":     nil,
+	}
+	if page > 1 {
+		pagination["prev_page"] = page - 1
+	}
+	if page < lastPage {
+		pagination["next_page"] = page + 1
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":     "success",
+		"pagination": pagination,
+		"data":       append([]interface{}{}, items[start:end]...),
+	})
+}
+
+func writeData(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, map[string]interface{}{"status": "success", "data": data})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"status": "error", "message": message})
+}
+
+// writeFieldError writes a 422 with a per-field message, as the API does for invalid requests
+func writeFieldError(w http.ResponseWriter, field, message string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"status":  "error",
+		"message": "Validation failed",
+		"errors":  map[string]interface{}{field: []string{message}},
+	})
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, r.Method+" is not allowed on "+r.URL.Path)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -12,7 +12,7 @@ import (
 func TestAccDataSourceSync_Basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceSyncConfig_basic(),
@@ -0,0 +1,26 @@
+package acceptance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sutrolabs/terraform-provider-census/census/tests/fakeapi"
+)
+
+// TestMain runs the acceptance tests against an in-process fake Census API when CENSUS_TEST_FAKE_API
+// is set, replacing the Census, Redshift and Salesforce environment with the fake's
+func TestMain(m *testing.M) {
+	if os.Getenv("CENSUS_TEST_FAKE_API") == "" {
+		os.Exit(m.Run())
+	}
+
+	server := fakeapi.New()
+	for key, value := range server.Env() {
+		os.Setenv(key, value)
+	}
+	os.Unsetenv("CENSUS_WORKSPACE_ACCESS_TOKEN")
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
@@ -13,7 +13,7 @@ import (
 func TestAccResourceDataset_Basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceDatasetConfig_basic(),
@@ -35,7 +35,7 @@ func TestAccResourceDataset_Basic(t *testing.T) {
 func TestAccResourceDataset_Update(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceDatasetConfig_basic(),
@@ -58,7 +58,7 @@ func TestAccResourceDataset_Update(t *testing.T) {
 func TestAccResourceDataset_WithSync(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceDatasetConfig_withSync(),
@@ -320,7 +320,7 @@ resource "census_sync" "dataset_sync" {
 func TestAccResourceDataset_Import(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceDatasetConfig_basic(),
@@ -13,7 +13,7 @@ import (
 func TestAccResourceDestination_Basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceDestinationConfig_salesforce(),
@@ -32,7 +32,7 @@ func TestAccResourceDestination_Basic(t *testing.T) {
 func TestAccResourceDestination_Update(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceDestinationConfig_salesforce(),
@@ -117,7 +117,7 @@ resource "census_destination" "test" {
 func TestAccResourceDestination_Import(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceDestinationConfig_salesforce(),
@@ -14,7 +14,7 @@ import (
 func TestAccResourceSync_Basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSyncConfig_basic(),
@@ -34,7 +34,7 @@ func TestAccResourceSync_Basic(t *testing.T) {
 func TestAccResourceSync_Update(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSyncConfig_basic(),
@@ -58,7 +58,7 @@ func TestAccResourceSync_Update(t *testing.T) {
 func TestAccResourceSync_FieldMappings(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSyncConfig_fieldMappings(),
@@ -66,12 +66,16 @@ func TestAccResourceSync_FieldMappings(t *testing.T) {
 					resource.TestCheckResourceAttr("census_sync.test", "label", "Test Field Mappings"),
 					resource.TestCheckResourceAttr("census_sync.test", "field_mapping.#", "5"),
 					// Direct mapping
-					resource.TestCheckResourceAttr("census_sync.test", "field_mapping.0.from", "email"),
-					resource.TestCheckResourceAttr("census_sync.test", "field_mapping.0.to", "Email"),
-					resource.TestCheckResourceAttr("census_sync.test", "field_mapping.0.is_primary_identifier", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs("census_sync.test", "field_mapping.*", map[string]string{
+						"from":                  "email",
+						"to":                    "Email",
+						"is_primary_identifier": "true",
+					}),
 					// Constant mapping
-					resource.TestCheckResourceAttr("census_sync.test", "field_mapping.3.type", "constant"),
-					resource.TestCheckResourceAttr("census_sync.test", "field_mapping.3.to", "LeadSource"),
+					resource.TestCheckTypeSetElemNestedAttrs("census_sync.test", "field_mapping.*", map[string]string{
+						"type": "constant",
+						"to":   "LeadSource",
+					}),
 				),
 			},
 		},
@@ -82,7 +86,7 @@ func TestAccResourceSync_FieldMappings(t *testing.T) {
 func TestAccResourceSync_RunMode_Daily(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSyncConfig_runModeDaily(),
@@ -102,7 +106,7 @@ func TestAccResourceSync_RunMode_Daily(t *testing.T) {
 func TestAccResourceSync_RunMode_Hourly(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSyncConfig_runModeHourly(),
@@ -121,7 +125,7 @@ func TestAccResourceSync_RunMode_Hourly(t *testing.T) {
 func TestAccResourceSync_RunMode_Manual(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSyncConfig_runModeManual(),
@@ -139,7 +143,7 @@ func TestAccResourceSync_RunMode_Manual(t *testing.T) {
 func TestAccResourceSync_Alerts(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSyncConfig_alerts(),
@@ -734,7 +738,7 @@ resource "census_sync" "test" {
 func TestAccResourceSync_Import(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { provider_test.TestAccPreCheckIntegration(t) },
-		Providers: provider_test.TestAccProviders,
+		Providers: provider_test.TestAccRecordedProviders(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSyncConfig_basic(),
@@ -1,11 +1,16 @@
 package provider_test
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sutrolabs/terraform-provider-census/census/provider"
+	"github.com/sutrolabs/terraform-provider-census/census/tests/recorder"
 )
 
 // TestAccProviders is a shared map of providers used across all acceptance tests
@@ -68,3 +73,65 @@ func TestAccPreCheckIntegration(t *testing.T) {
 		}
 	}
 }
+
+// recordedVariables are the environment variables saved in cassettes. Secret values are replaced
+// with placeholders, which replays then use as the values.
+var recordedVariables = []recorder.Variable{
+	{Name: "CENSUS_BASE_URL"},
+	{Name: "CENSUS_PERSONAL_ACCESS_TOKEN", Secret: true},
+	{Name: "CENSUS_TEST_REDSHIFT_HOST", Secret: true},
+	{Name: "CENSUS_TEST_REDSHIFT_PORT"},
+	{Name: "CENSUS_TEST_REDSHIFT_DATABASE"},
+	{Name: "CENSUS_TEST_REDSHIFT_USERNAME", Secret: true},
+	{Name: "CENSUS_TEST_REDSHIFT_PASSWORD", Secret: true},
+	{Name: "CENSUS_TEST_SALESFORCE_USERNAME", Secret: true},
+	{Name: "CENSUS_TEST_SALESFORCE_INSTANCE_URL", Secret: true},
+	{Name: "CENSUS_TEST_SALESFORCE_CLIENT_ID", Secret: true},
+	{Name: "CENSUS_TEST_SALESFORCE_JWT_SIGNING_KEY", Secret: true},
+	{Name: "CENSUS_TEST_SALESFORCE_DOMAIN"},
+}
+
+// TestAccRecordedProviders returns providers whose Census API requests go through a recorder for the
+// test's cassette in census/tests/cassettes, in the mode set by CENSUS_TEST_RECORDER. Replays set the
+// environment the cassette was recorded with and fail the test when it has no cassette. Without
+// CENSUS_TEST_RECORDER this is TestAccProviders.
+func TestAccRecordedProviders(t *testing.T) map[string]*schema.Provider {
+	t.Helper()
+
+	mode, err := recorder.ModeFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode == recorder.ModePassthrough {
+		return TestAccProviders
+	}
+
+	rec, err := recorder.New(cassettePath(t), mode, recordedVariables)
+	if errors.Is(err, recorder.ErrNoCassette) {
+		t.Fatalf("%v, record it with %s=%s", err, recorder.ModeEnvVar, recorder.ModeRecord)
+	}
+	if err != nil {
+		t.Fatalf("failed to start recorder: %v", err)
+	}
+	if mode == recorder.ModeReplay {
+		for name, value := range rec.Variables() {
+			t.Setenv(name, value)
+		}
+	}
+	t.Cleanup(func() {
+		if err := rec.Stop(); err != nil {
+			t.Errorf("failed to save cassette: %v", err)
+		}
+	})
+
+	return map[string]*schema.Provider{
+		"census": provider.ProviderWithHTTPClient(rec.Client()),
+	}
+}
+
+// cassettePath returns the cassette file of a test, next to this package
+func cassettePath(t *testing.T) string {
+	_, file, _, _ := runtime.Caller(0)
+	name := strings.ReplaceAll(t.Name(), "/", "_")
+	return filepath.Join(filepath.Dir(file), "..", "cassettes", name+".json")
+}
@@ -0,0 +1,292 @@
+package unit_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/sutrolabs/terraform-provider-census/census/clone"
+	"github.com/sutrolabs/terraform-provider-census/census/export"
+)
+
+func atoi(t *testing.T, s string) int {
+	t.Helper()
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		t.Fatalf("invalid ID %q: %v", s, err)
+	}
+	return i
+}
+
+// promotionTarget creates a workspace with its own source and destination, and a dataset with the
+// same name as one in staging
+func promotionTarget(t *testing.T, p *schema.Provider, name string) map[string]*terraform.InstanceState {
+	t.Helper()
+
+	workspace := applyResource(t, p, "census_workspace", nil, map[string]interface{}{"name": name})
+	source := applyResource(t, p, "census_source", nil, map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "Production warehouse",
+		"type":         "redshift",
+		"connection_config": map[string]interface{}{
+			"hostname": "prod.example.com", "port": "5439", "database": "dev", "user": "census", "password": "hunter3",
+		},
+	})
+	destination := applyResource(t, p, "census_destination", nil, map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "Production CRM",
+		"type":         "salesforce",
+		"connection_config": map[string]interface{}{
+			"username": "census@example.com", "instance_url": "https://prod.my.salesforce.com",
+			"client_id": "client", "jwt_signing_key": "private-key",
+		},
+	})
+	dataset := applyResource(t, p, "census_dataset", nil, map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "Active users",
+		"type":         "sql",
+		"source_id":    source.ID,
+		"query":        "SELECT id, email, first_name, last_name FROM users WHERE active",
+	})
+	return map[string]*terraform.InstanceState{"workspace": workspace, "source": source, "destination": destination, "dataset": dataset}
+}
+
+func resultsByName(report *clone.Report) map[string]clone.Result {
+	results := map[string]clone.Result{}
+	for _, result := range report.Results {
+		results[result.Name] = result
+	}
+	return results
+}
+
+func TestClone_CopiesDatasetsAndSyncsWithRemappedIDs(t *testing.T) {
+	p, _ := fakeAPIProvider(t)
+	ctx := context.Background()
+
+	staging := exportedWorkspace(t, p, "Staging")
+	stagingID := staging["workspace"].ID
+	recent := applyResource(t, p, "census_dataset", nil, map[string]interface{}{
+		"workspace_id": stagingID,
+		"name":         "Recent users",
+		"type":         "sql",
+		"source_id":    staging["source"].ID,
+		"query":        "SELECT id, email FROM users",
+	})
+	unmappedSource := applyResource(t, p, "census_source", nil, map[string]interface{}{
+		"workspace_id": stagingID,
+		"name":         "Scratch",
+		"type":         "postgres",
+		"connection_config": map[string]interface{}{
+			"hostname": "scratch.example.com", "port": "5432", "database": "dev", "user": "census", "password": "hunter2",
+		},
+	})
+	unmapped := applyResource(t, p, "census_dataset", nil, map[string]interface{}{
+		"workspace_id": stagingID,
+		"name":         "Scratch users",
+		"type":         "sql",
+		"source_id":    unmappedSource.ID,
+		"query":        "SELECT id, email, last_name FROM users",
+	})
+	scratchSync := fakeSyncConfig(stagingID, unmappedSource.ID, staging["destination"].ID, "Scratch to contacts")
+	scratchSync["source_attributes"] = []interface{}{map[string]interface{}{
+		"connection_id": unmappedSource.ID,
+		"object":        []interface{}{map[string]interface{}{"type": "dataset", "id": unmapped.ID}},
+	}}
+	applyResource(t, p, "census_sync", nil, scratchSync)
+
+	production := promotionTarget(t, p, "Production")
+	opts := clone.Options{
+		SourceWorkspaceID: atoi(t, stagingID),
+		TargetWorkspaceID: atoi(t, production["workspace"].ID),
+		Mapping: clone.Mapping{
+			Sources:      map[int]int{atoi(t, staging["source"].ID): atoi(t, production["source"].ID)},
+			Destinations: map[int]int{atoi(t, staging["destination"].ID): atoi(t, production["destination"].ID)},
+		},
+	}
+
+	report, err := clone.Clone(ctx, p, opts)
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	results := resultsByName(report)
+	for name, want := range map[string]clone.Status{
+		"Active users":        clone.StatusSkipped,
+		"Recent users":        clone.StatusCreated,
+		"Scratch users":       clone.StatusFailed,
+		"Users to contacts":   clone.StatusCreated,
+		"Users to leads":      clone.StatusCreated,
+		"Scratch to contacts": clone.StatusFailed,
+	} {
+		if got := results[name].Status; got != want {
+			t.Errorf("%s status = %q (%s), want %q", name, got, results[name].Reason, want)
+		}
+	}
+	if got := results["Active users"].TargetID; got != atoi(t, production["dataset"].ID) {
+		t.Errorf("skipped dataset target ID = %d, want the existing dataset %s", got, production["dataset"].ID)
+	}
+	if reason := results["Scratch users"].Reason; !strings.Contains(reason, "source "+unmappedSource.ID+" is not in the mapping") {
+		t.Errorf("unmapped dataset reason = %q", reason)
+	}
+	if reason := results["Scratch to contacts"].Reason; !strings.Contains(reason, "source "+unmappedSource.ID+" is not in the mapping") {
+		t.Errorf("unmapped sync reason = %q", reason)
+	}
+	if results["Recent users"].SourceID != atoi(t, recent.ID) {
+		t.Errorf("Recent users source ID = %d, want %s", results["Recent users"].SourceID, recent.ID)
+	}
+
+	// The cloned sync reads the existing production dataset and waits for the cloned contacts sync
+	leads, err := export.ReadConfig(ctx, p, "census_sync", opts.TargetWorkspaceID, results["Users to leads"].TargetID)
+	if err != nil || leads == nil {
+		t.Fatalf("ReadConfig(cloned sync) = %v, %v", leads, err)
+	}
+	sourceAttributes := leads["source_attributes"].([]interface{})[0].(map[string]interface{})
+	object := sourceAttributes["object"].([]interface{})[0].(map[string]interface{})
+	if sourceAttributes["connection_id"] != opts.Mapping.Sources[atoi(t, staging["source"].ID)] || object["id"] != production["dataset"].ID {
+		t.Errorf("cloned sync source_attributes = %v, want the production source and dataset", sourceAttributes)
+	}
+	destinationAttributes := leads["destination_attributes"].([]interface{})[0].(map[string]interface{})
+	if destinationAttributes["connection_id"] != atoi(t, production["destination"].ID) {
+		t.Errorf("cloned sync destination_attributes = %v, want the production destination", destinationAttributes)
+	}
+	sequence := leads["run_mode"].([]interface{})[0].(map[string]interface{})["triggers"].([]interface{})[0].(map[string]interface{})["sync_sequence"].([]interface{})[0].(map[string]interface{})
+	if sequence["sync_id"] != results["Users to contacts"].TargetID {
+		t.Errorf("cloned sync_sequence sync_id = %v, want the cloned contacts sync %d", sequence["sync_id"], results["Users to contacts"].TargetID)
+	}
+	if leads["workspace_id"] != production["workspace"].ID {
+		t.Errorf("cloned sync workspace_id = %v, want %s", leads["workspace_id"], production["workspace"].ID)
+	}
+
+	// Cloning again skips everything that was created
+	report, err = clone.Clone(ctx, p, opts)
+	if err != nil {
+		t.Fatalf("second Clone() error = %v", err)
+	}
+	if got := report.Count(clone.StatusCreated); got != 0 {
+		t.Errorf("second clone created %d objects, want 0", got)
+	}
+	if got := report.Count(clone.StatusSkipped); got != 4 {
+		t.Errorf("second clone skipped %d objects, want 4", got)
+	}
+}
+
+func TestClone_MatchesSyncsOnLabelAndConnections(t *testing.T) {
+	p, _ := fakeAPIProvider(t)
+	ctx := context.Background()
+
+	staging := exportedWorkspace(t, p, "Staging")
+	applyResource(t, p, "census_sync", nil, fakeSyncConfig(staging["workspace"].ID, staging["source"].ID, staging["destination"].ID, ""))
+
+	production := promotionTarget(t, p, "Production")
+	productionID := production["workspace"].ID
+	otherDestination := applyResource(t, p, "census_destination", nil, map[string]interface{}{
+		"workspace_id": productionID,
+		"name":         "Other CRM",
+		"type":         "salesforce",
+		"connection_config": map[string]interface{}{
+			"username": "census@example.com", "instance_url": "https://other.my.salesforce.com",
+			"client_id": "client", "jwt_signing_key": "private-key",
+		},
+	})
+	// The same label writing elsewhere is an unrelated sync, and an unlabelled sync between the mapped
+	// connections cannot be told apart from a copy
+	unrelated := applyResource(t, p, "census_sync", nil, fakeSyncConfig(productionID, production["source"].ID, otherDestination.ID, "Users to contacts"))
+	unlabelled := applyResource(t, p, "census_sync", nil, fakeSyncConfig(productionID, production["source"].ID, production["destination"].ID, ""))
+
+	report, err := clone.Clone(ctx, p, clone.Options{
+		SourceWorkspaceID: atoi(t, staging["workspace"].ID),
+		TargetWorkspaceID: atoi(t, productionID),
+		Mapping: clone.Mapping{
+			Sources:      map[int]int{atoi(t, staging["source"].ID): atoi(t, production["source"].ID)},
+			Destinations: map[int]int{atoi(t, staging["destination"].ID): atoi(t, production["destination"].ID)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	results := resultsByName(report)
+	contacts := results["Users to contacts"]
+	if contacts.Status != clone.StatusCreated || contacts.TargetID == atoi(t, unrelated.ID) {
+		t.Errorf("contacts sync = %+v, want it created rather than matched to sync %s", contacts, unrelated.ID)
+	}
+	if got := results["Users to leads"]; got.Status != clone.StatusCreated {
+		t.Errorf("leads sync = %+v, want created", got)
+	}
+	if got := results[""]; got.Status != clone.StatusFailed || !strings.Contains(got.Reason, "no label") || !strings.Contains(got.Reason, unlabelled.ID) {
+		t.Errorf("unlabelled sync = %+v, want failed because it matches sync %s", got, unlabelled.ID)
+	}
+}
+
+func TestClone_DryRunCreatesNothing(t *testing.T) {
+	p, server := fakeAPIProvider(t)
+	ctx := context.Background()
+
+	staging := exportedWorkspace(t, p, "Staging")
+	production := promotionTarget(t, p, "Production")
+	syncs, datasets := server.Count("syncs"), server.Count("datasets")
+
+	report, err := clone.Clone(ctx, p, clone.Options{
+		SourceWorkspaceID: atoi(t, staging["workspace"].ID),
+		TargetWorkspaceID: atoi(t, production["workspace"].ID),
+		Mapping: clone.Mapping{
+			Sources:      map[int]int{atoi(t, staging["source"].ID): atoi(t, production["source"].ID)},
+			Destinations: map[int]int{atoi(t, staging["destination"].ID): atoi(t, production["destination"].ID)},
+			Datasets:     map[int]int{atoi(t, staging["dataset"].ID): atoi(t, production["dataset"].ID)},
+		},
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	results := resultsByName(report)
+	if got := results["Active users"]; got.Status != clone.StatusSkipped || !strings.Contains(got.Reason, "mapped") {
+		t.Errorf("mapped dataset = %+v, want skipped as mapped", got)
+	}
+	if got := results["Users to contacts"]; got.Status != clone.StatusWouldCreate || got.Reason != "" {
+		t.Errorf("contacts sync = %+v, want would_create after planning", got)
+	}
+	if got := results["Users to leads"]; got.Status != clone.StatusWouldCreate || !strings.Contains(got.Reason, "not validated") {
+		t.Errorf("leads sync = %+v, want would_create without validation", got)
+	}
+	if server.Count("syncs") != syncs || server.Count("datasets") != datasets {
+		t.Errorf("dry run created objects: %d syncs and %d datasets, want %d and %d", server.Count("syncs"), server.Count("datasets"), syncs, datasets)
+	}
+}
+
+func TestClone_CommandWritesReport(t *testing.T) {
+	p, server := fakeAPIProvider(t)
+	t.Setenv("CENSUS_PERSONAL_ACCESS_TOKEN", "fake-personal-access-token")
+	t.Setenv("CENSUS_BASE_URL", server.URL)
+
+	staging := exportedWorkspace(t, p, "Staging")
+	production := promotionTarget(t, p, "Production")
+
+	dir := t.TempDir()
+	mapping := filepath.Join(dir, "mapping.json")
+	if err := os.WriteFile(mapping, []byte(`{"sources": {"`+staging["source"].ID+`": `+production["source"].ID+`}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reportPath := filepath.Join(dir, "report.json")
+
+	var stdout, stderr strings.Builder
+	code := clone.Main(context.Background(), []string{
+		"-from", staging["workspace"].ID, "-to", production["workspace"].ID, "-map", mapping, "-report", reportPath,
+	}, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("clone exited with %d, want 1 because the destination is not mapped (stderr: %s)", code, stderr.String())
+	}
+	for _, want := range []string{"skipped", "Active users", "destination " + staging["destination"].ID + " is not in the mapping", "0 created, 1 skipped, 2 failed"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("clone output does not contain %q:\n%s", want, stdout.String())
+		}
+	}
+	if data, err := os.ReadFile(reportPath); err != nil || !strings.Contains(string(data), `"status": "failed"`) {
+		t.Errorf("JSON report = %s, %v", data, err)
+	}
+}
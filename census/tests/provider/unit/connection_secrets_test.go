@@ -0,0 +1,442 @@
+package unit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+// fakeSourceAPI records the credentials sent for a postgres source and serves connection as the
+// public connection details of the source
+type fakeSourceAPI struct {
+	t           *testing.T
+	mu          sync.Mutex
+	credentials []map[string]interface{}
+	connection  map[string]interface{}
+	// flakyCatalog fails every second /source_types request. Credentials are validated against the
+	// catalog first, so the following lookup of the secret fields fails.
+	flakyCatalog bool
+	catalogCalls int
+}
+
+func (f *fakeSourceAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/workspaces/1/api_key":
+		w.Write([]byte(`{"api_key": "workspace-token"}`))
+	case r.URL.Path == "/source_types" && f.flakyCatalog && f.catalogCalls%2 == 1:
+		f.catalogCalls++
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"status": "error", "message": "catalog unavailable"}`))
+	case r.URL.Path == "/source_types":
+		f.catalogCalls++
+		w.Write([]byte(`{"status": "success", "data": [{"service_name": "postgres", "configuration_fields": {"fields": [
+			{"id": "host", "rules": ["required"]},
+			{"id": "password", "rules": ["required"], "is_password_type_field": true}
+		]}}]}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/sources", r.Method == http.MethodPatch && r.URL.Path == "/sources/1":
+		var body struct {
+			Connection client.SourceConnection `json:"connection"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			f.t.Errorf("failed to decode %s request: %v", r.Method, err)
+		}
+		f.credentials = append(f.credentials, body.Connection.Credentials)
+		w.Write([]byte(`{"status": "success", "data": {"id": 1, "name": "Warehouse", "type": "postgres"}}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/sources/1":
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "data": map[string]interface{}{
+			"id": 1, "name": "Warehouse", "type": "postgres", "connection": f.connection,
+		}})
+	default:
+		f.t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func sourceConfig(password, credentialsVersion string) map[string]interface{} {
+	return map[string]interface{}{
+		"workspace_id":        "1",
+		"name":                "Warehouse",
+		"type":                "postgres",
+		"connection_config":   map[string]interface{}{"host": "db.example.com"},
+		"connection_secrets":  map[string]interface{}{"password": password},
+		"credentials_version": credentialsVersion,
+	}
+}
+
+// planConnection plans raw against state as Terraform does, passing the raw config along
+func planConnection(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceState, *terraform.InstanceDiff) {
+	t.Helper()
+
+	value, err := ctyJSON(t, raw, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("failed to build config: %v", err)
+	}
+	if state == nil {
+		state = &terraform.InstanceState{}
+	}
+	state.RawConfig = value
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(value, r.CoreConfigSchema()), nil)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error: %v", err)
+	}
+	return state, diff
+}
+
+func assertNoSecret(t *testing.T, where string, attributes map[string]string, secret string) {
+	t.Helper()
+
+	for key, value := range attributes {
+		if strings.Contains(value, secret) {
+			t.Errorf("%s %s contains the secret", where, key)
+		}
+	}
+}
+
+func TestResourceSource_ConnectionSecretsAreWriteOnly(t *testing.T) {
+	api := &fakeSourceAPI{t: t}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	r := provider.Provider().ResourcesMap["census_source"]
+
+	// Create sends the secret without planning or storing it
+	state, diff := planConnection(t, r, nil, sourceConfig("hunter2", "1"))
+	for key, attr := range diff.Attributes {
+		if strings.HasPrefix(key, "connection_secrets") || strings.Contains(attr.New, "hunter2") {
+			t.Errorf("create plan contains %s = %q", key, attr.New)
+		}
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("failed to apply diff: %v", err)
+	}
+	if diags := r.CreateContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("create failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	if got := api.credentials[0]; got["host"] != "db.example.com" || got["password"] != "hunter2" {
+		t.Errorf("create sent credentials %v, want host and password", got)
+	}
+	state = d.State()
+	assertNoSecret(t, "state", state.Attributes, "hunter2")
+
+	// The unchanged configuration plans no changes
+	state, diff = planConnection(t, r, state, sourceConfig("hunter2", "1"))
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("Diff() = %v, want no changes", diff.Attributes)
+	}
+
+	// A new credentials_version sends the new secret
+	state, diff = planConnection(t, r, state, sourceConfig("correct horse", "2"))
+	if diff == nil || diff.Attributes["credentials_version"] == nil {
+		t.Fatalf("Diff() = %v, want credentials_version to change", diff)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("failed to apply diff: %v", err)
+	}
+	if diags := r.UpdateContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("update failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	if got := api.credentials[1]; got["password"] != "correct horse" {
+		t.Errorf("update sent credentials %v, want the new password", got)
+	}
+	assertNoSecret(t, "state", d.State().Attributes, "correct horse")
+}
+
+// applyConnection plans raw against state and runs Create or Update on the result
+func applyConnection(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, apiClient *client.Client) *terraform.InstanceState {
+	t.Helper()
+
+	state, diff := planConnection(t, r, state, raw)
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("failed to apply diff: %v", err)
+	}
+	var diags diag.Diagnostics
+	if state.ID == "" {
+		diags = r.CreateContext(context.Background(), d, apiClient)
+	} else {
+		diags = r.UpdateContext(context.Background(), d, apiClient)
+	}
+	if diags.HasError() {
+		t.Fatalf("apply failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	return d.State()
+}
+
+func TestResourceSource_SecretConnectionFieldsAreHashed(t *testing.T) {
+	api := &fakeSourceAPI{t: t}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	r := provider.Provider().ResourcesMap["census_source"]
+	config := func(host, password string) map[string]interface{} {
+		return map[string]interface{}{
+			"workspace_id":      "1",
+			"name":              "Warehouse",
+			"type":              "postgres",
+			"connection_config": map[string]interface{}{"host": host, "password": password},
+		}
+	}
+
+	// The catalog marks password as secret, so only its salted hash is stored
+	api.connection = map[string]interface{}{"host": "db.example.com"}
+	state := applyConnection(t, r, nil, config("db.example.com", "hunter2"), apiClient)
+	assertNoSecret(t, "state", state.Attributes, "hunter2")
+	if got := state.Attributes["connection_config.password"]; !strings.HasPrefix(got, "hmac-sha256:") {
+		t.Errorf("stored password = %q, want a salted hash", got)
+	}
+	if got := state.Attributes["connection_config.host"]; got != "db.example.com" {
+		t.Errorf("stored host = %q, want db.example.com", got)
+	}
+
+	// The same password matches its hash
+	if _, diff := planConnection(t, r, state, config("db.example.com", "hunter2")); diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("Diff() = %v, want no changes", diff.Attributes)
+	}
+
+	// A new password is planned and sent as configured, never as the stored hash
+	_, diff := planConnection(t, r, state, config("db.example.com", "correct horse"))
+	if diff == nil || diff.Attributes["connection_config.password"] == nil {
+		t.Fatalf("Diff() = %v, want connection_config.password to change", diff)
+	}
+	state = applyConnection(t, r, state, config("db.example.com", "correct horse"), apiClient)
+	if got := api.credentials[1]; got["password"] != "correct horse" || got["host"] != "db.example.com" {
+		t.Errorf("update sent credentials %v, want the new password and host", got)
+	}
+	assertNoSecret(t, "state", state.Attributes, "correct horse")
+
+	// A public field changed outside Terraform is read back and planned
+	api.connection = map[string]interface{}{"host": "db2.example.com"}
+	d := r.Data(state)
+	if diags := r.ReadContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("read failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	_, diff = planConnection(t, r, d.State(), config("db.example.com", "correct horse"))
+	if diff == nil || diff.Attributes["connection_config.host"] == nil || diff.Attributes["connection_config.password"] != nil {
+		t.Errorf("Diff() after drift = %v, want only connection_config.host to change", diff)
+	}
+}
+
+func TestResourceSource_ConnectionConfigIsHashedWhenCatalogFails(t *testing.T) {
+	api := &fakeSourceAPI{t: t, flakyCatalog: true, connection: map[string]interface{}{"host": "db.example.com"}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	r := provider.Provider().ResourcesMap["census_source"]
+	config := func(password string) map[string]interface{} {
+		return map[string]interface{}{
+			"workspace_id":      "1",
+			"name":              "Warehouse",
+			"type":              "postgres",
+			"connection_config": map[string]interface{}{"host": "db.example.com", "password": password},
+		}
+	}
+
+	// Without the catalog no field is known to be public, so every value is stored as a hash
+	state := applyConnection(t, r, nil, config("hunter2"), apiClient)
+	if got := api.credentials[0]; got["host"] != "db.example.com" || got["password"] != "hunter2" {
+		t.Errorf("create sent credentials %v, want host and password", got)
+	}
+	for _, key := range []string{"connection_config.host", "connection_config.password"} {
+		if got := state.Attributes[key]; !strings.HasPrefix(got, "hmac-sha256:") {
+			t.Errorf("stored %s = %q, want a salted hash", key, got)
+		}
+	}
+	assertNoSecret(t, "state", state.Attributes, "hunter2")
+
+	// The hashes survive a read and match the unchanged configuration
+	d := r.Data(state)
+	if diags := r.ReadContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("read failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	state = d.State()
+	assertNoSecret(t, "state after read", state.Attributes, "hunter2")
+	if _, diff := planConnection(t, r, state, config("hunter2")); diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("Diff() = %v, want no changes", diff.Attributes)
+	}
+
+	// A changed value is still planned and stored as a hash
+	state = applyConnection(t, r, state, config("correct horse"), apiClient)
+	if got := api.credentials[1]; got["password"] != "correct horse" {
+		t.Errorf("update sent credentials %v, want the new password", got)
+	}
+	assertNoSecret(t, "state after update", state.Attributes, "correct horse")
+}
+
+func TestResourceDestination_RejectsSecretsAlsoInConnectionConfig(t *testing.T) {
+	r := provider.Provider().ResourcesMap["census_destination"]
+	raw := map[string]interface{}{
+		"workspace_id":       "1",
+		"name":               "CRM",
+		"type":               "salesforce",
+		"connection_config":  map[string]interface{}{"username": "census", "password": "hunter2"},
+		"connection_secrets": map[string]interface{}{"password": "hunter2"},
+	}
+
+	value, err := ctyJSON(t, raw, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("failed to build config: %v", err)
+	}
+	_, err = r.Diff(context.Background(), &terraform.InstanceState{RawConfig: value}, terraform.NewResourceConfigShimmed(value, r.CoreConfigSchema()), nil)
+	want := `connection_secrets["password"]: also set in connection_config`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Diff() error = %v, want it to contain %q", err, want)
+	}
+}
+
+// TestResourceSource_ConnectionSecretsAreNotPersistedThroughGRPC plans and applies through the gRPC
+// server, where the SDK decides which values are written to the plan and the new state
+func TestResourceSource_ConnectionSecretsAreNotPersistedThroughGRPC(t *testing.T) {
+	api := &fakeSourceAPI{t: t, connection: map[string]interface{}{"host": "db.example.com"}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	p := provider.Provider()
+	grpc := schema.NewGRPCProviderServer(p)
+	ctx := context.Background()
+
+	providerType := schema.InternalMap(p.Schema).CoreConfigSchema().ImpliedType()
+	providerConfig, err := ctyJSON(t, map[string]interface{}{"personal_access_token": "test-token", "base_url": server.URL}, providerType)
+	if err != nil {
+		t.Fatalf("failed to build provider config: %v", err)
+	}
+	configureResp, err := grpc.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: msgpackValue(t, providerConfig, providerType)})
+	if err != nil || len(configureResp.Diagnostics) > 0 {
+		t.Fatalf("ConfigureProvider() = %v, %v", configureResp, err)
+	}
+
+	r := p.ResourcesMap["census_source"]
+	ty := r.CoreConfigSchema().ImpliedType()
+	apply := func(prior cty.Value, raw map[string]interface{}) cty.Value {
+		t.Helper()
+
+		config, err := ctyJSON(t, raw, ty)
+		if err != nil {
+			t.Fatalf("failed to build config: %v", err)
+		}
+		planResp, err := grpc.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "census_source",
+			PriorState:       msgpackValue(t, prior, ty),
+			ProposedNewState: msgpackValue(t, proposedNewState(r, prior, config), ty),
+			Config:           msgpackValue(t, config, ty),
+		})
+		if err != nil || len(planResp.Diagnostics) > 0 {
+			t.Fatalf("PlanResourceChange() = %v, %v", planResp.Diagnostics, err)
+		}
+		assertNoPersistedSecrets(t, "planned state", planResp.PlannedState.MsgPack, ty)
+
+		applyResp, err := grpc.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+			TypeName:       "census_source",
+			PriorState:     msgpackValue(t, prior, ty),
+			PlannedState:   planResp.PlannedState,
+			Config:         msgpackValue(t, config, ty),
+			PlannedPrivate: planResp.PlannedPrivate,
+		})
+		if err != nil || len(applyResp.Diagnostics) > 0 {
+			t.Fatalf("ApplyResourceChange() = %v, %v", applyResp.Diagnostics, err)
+		}
+		return assertNoPersistedSecrets(t, "new state", applyResp.NewState.MsgPack, ty)
+	}
+
+	state := apply(cty.NullVal(ty), sourceConfig("hunter2", "1"))
+	if got := api.credentials[0]; got["password"] != "hunter2" {
+		t.Errorf("create sent credentials %v, want the password", got)
+	}
+
+	apply(state, sourceConfig("correct horse", "2"))
+	if len(api.credentials) != 2 || api.credentials[1]["password"] != "correct horse" {
+		t.Errorf("update sent credentials %v, want the new password", api.credentials)
+	}
+}
+
+func msgpackValue(t *testing.T, v cty.Value, ty cty.Type) *tfprotov5.DynamicValue {
+	t.Helper()
+
+	b, err := msgpack.Marshal(v, ty)
+	if err != nil {
+		t.Fatalf("failed to encode value: %v", err)
+	}
+	return &tfprotov5.DynamicValue{MsgPack: b}
+}
+
+// proposedNewState merges config with prior as Terraform does before planning: computed attributes
+// that are not configured keep their prior values
+func proposedNewState(r *schema.Resource, prior, config cty.Value) cty.Value {
+	if prior.IsNull() {
+		return config
+	}
+	proposed := config.AsValueMap()
+	priorValues := prior.AsValueMap()
+	for name, attribute := range r.CoreConfigSchema().Attributes {
+		if attribute.Computed && proposed[name].IsNull() {
+			proposed[name] = priorValues[name]
+		}
+	}
+	return cty.ObjectVal(proposed)
+}
+
+// assertNoPersistedSecrets fails when connection_secrets is set in an encoded plan or state, or when
+// any of its test values appears in it, and returns the decoded value
+func assertNoPersistedSecrets(t *testing.T, where string, encoded []byte, ty cty.Type) cty.Value {
+	t.Helper()
+
+	for _, secret := range []string{"hunter2", "correct horse"} {
+		if bytes.Contains(encoded, []byte(secret)) {
+			t.Errorf("%s contains the secret %q", where, secret)
+		}
+	}
+	value, err := msgpack.Unmarshal(encoded, ty)
+	if err != nil {
+		t.Fatalf("failed to decode %s: %v", where, err)
+	}
+	if secrets := value.GetAttr("connection_secrets"); !secrets.IsNull() {
+		t.Errorf("%s connection_secrets = %#v, want null", where, secrets)
+	}
+	return value
+}
@@ -0,0 +1,103 @@
+package unit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+func TestConnectorCatalogFields_NormalizesRules(t *testing.T) {
+	connector := client.Connector{
+		ServiceName: "salesforce",
+		ConfigurationFields: client.ConnectorConfiguration{Fields: []client.ConnectorField{
+			{ID: "instance_url", Rules: "required"},
+			{ID: "password", Rules: []interface{}{"required:notForEditing", "min:8"}, IsPasswordTypeField: true},
+			{ID: "sandbox"},
+		}},
+	}
+
+	fields := provider.ConnectorCatalogFields(connector)
+	if len(fields) != 3 {
+		t.Fatalf("ConnectorCatalogFields() returned %d fields, want 3", len(fields))
+	}
+
+	tests := []struct {
+		field    provider.CatalogField
+		rules    []string
+		required bool
+	}{
+		{fields[0], []string{"required"}, true},
+		{fields[1], []string{"required:notForEditing", "min:8"}, true},
+		{fields[2], nil, false},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.field.Rules, tt.rules) {
+			t.Errorf("%s rules = %v, want %v", tt.field.ID, tt.field.Rules, tt.rules)
+		}
+		if tt.field.Required() != tt.required {
+			t.Errorf("%s Required() = %v, want %v", tt.field.ID, tt.field.Required(), tt.required)
+		}
+	}
+	if !fields[1].IsPassword {
+		t.Error("password IsPassword = false, want true")
+	}
+}
+
+func TestDataSourceDestinationTypes_ReadsEveryPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1/api_key":
+			w.Write([]byte(`{"api_key": "workspace-token"}`))
+		case "/connectors":
+			if r.URL.Query().Get("page") == "1" {
+				w.Write([]byte(`{"status": "success", "data": [
+					{"service_name": "salesforce", "label": "Salesforce", "creatable_via_api": true, "configuration_fields": {"fields": [
+						{"id": "instance_url", "label": "Instance URL", "type": "string", "rules": "required"},
+						{"id": "environment", "label": "Environment", "type": "select", "possible_values": ["production", "sandbox"], "show": {"if": "advanced"}}
+					]}}
+				], "pagination": {"page": 1, "next_page": 2, "last_page": 2}}`))
+				return
+			}
+			w.Write([]byte(`{"status": "success", "data": [
+				{"service_name": "hubspot", "label": "HubSpot", "configuration_fields": {"fields": []}}
+			], "pagination": {"page": 2, "next_page": null, "last_page": 2}}`))
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ds := provider.Provider().DataSourcesMap["census_destination_types"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"workspace_id": "1",
+	})
+	if diags := ds.ReadContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("read failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	if got := d.Get("service_names").([]interface{}); !reflect.DeepEqual(got, []interface{}{"hubspot", "salesforce"}) {
+		t.Errorf("service_names = %v, want [hubspot salesforce]", got)
+	}
+	if !d.Get("destination_types.1.configuration_fields.0.required").(bool) {
+		t.Error("salesforce instance_url required = false, want true")
+	}
+	if got := d.Get("destination_types.1.configuration_fields.1.show").(string); got != `{"if":"advanced"}` {
+		t.Errorf("salesforce environment show = %q, want the JSON encoded condition", got)
+	}
+}
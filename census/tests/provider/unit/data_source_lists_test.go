@@ -0,0 +1,175 @@
+package unit_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+func syncIDs(syncs []client.Sync) []int {
+	ids := make([]int, 0, len(syncs))
+	for _, sync := range syncs {
+		ids = append(ids, sync.ID)
+	}
+	return ids
+}
+
+func TestFilterSyncs(t *testing.T) {
+	syncs := []client.Sync{
+		{ID: 3, Label: "Users to Salesforce", Status: "Ready", Paused: true,
+			SourceAttributes:      map[string]interface{}{"connection_id": float64(10)},
+			DestinationAttributes: map[string]interface{}{"connection_id": float64(20)}},
+		{ID: 1, Label: "Users to HubSpot", Status: "Ready",
+			SourceAttributes:      map[string]interface{}{"connection_id": float64(10)},
+			DestinationAttributes: map[string]interface{}{"connection_id": float64(21)}},
+		{ID: 2, Label: "Accounts to HubSpot", Status: "Failed",
+			SourceAttributes:      map[string]interface{}{"connection_id": float64(11)},
+			DestinationAttributes: map[string]interface{}{"connection_id": float64(21)}},
+	}
+	paused := false
+
+	tests := []struct {
+		name   string
+		filter provider.ListFilter
+		want   []int
+	}{
+		{"no filter returns all sorted by id", provider.ListFilter{}, []int{1, 2, 3}},
+		{"name regex", provider.ListFilter{NameRegex: regexp.MustCompile(`^Users`)}, []int{1, 3}},
+		{"status is case insensitive", provider.ListFilter{Status: "ready"}, []int{1, 3}},
+		{"paused false", provider.ListFilter{Paused: &paused}, []int{1, 2}},
+		{"source connection", provider.ListFilter{SourceConnectionID: "10"}, []int{1, 3}},
+		{"destination connection", provider.ListFilter{DestinationConnectionID: "21", Status: "Failed"}, []int{2}},
+		{"no match", provider.ListFilter{NameRegex: regexp.MustCompile(`Zendesk`)}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := syncIDs(provider.FilterSyncs(syncs, tt.filter)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterSyncs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterSourcesAndDatasets(t *testing.T) {
+	sources := []client.Source{
+		{ID: 2, Name: "Snowflake prod", Type: "snowflake"},
+		{ID: 1, Name: "Postgres", Type: "postgres"},
+	}
+	if got := provider.FilterSources(sources, provider.ListFilter{Type: "Snowflake"}); len(got) != 1 || got[0].ID != 2 {
+		t.Errorf("FilterSources() by type = %+v, want source 2", got)
+	}
+
+	datasets := []client.Dataset{
+		{ID: 1, Name: "active_users", Type: "sql", SourceID: 2},
+		{ID: 2, Name: "churned_users", Type: "sql", SourceID: 1},
+	}
+	if got := provider.FilterDatasets(datasets, provider.ListFilter{SourceConnectionID: "1"}); len(got) != 1 || got[0].ID != 2 {
+		t.Errorf("FilterDatasets() by source = %+v, want dataset 2", got)
+	}
+}
+
+func TestDataSourceSyncs_ReadsEveryPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1/api_key":
+			w.Write([]byte(`{"api_key": "workspace-token"}`))
+		case "/syncs":
+			if r.URL.Query().Get("page") == "1" {
+				w.Write([]byte(`{"status": "success", "data": [
+					{"id": 1, "label": "Active", "paused": false, "source_attributes": {"connection_id": 10, "object": {"type": "table"}}},
+					{"id": 2, "label": "Paused", "paused": true}
+				], "pagination": {"page": 1, "next_page": 2, "last_page": 2}}`))
+				return
+			}
+			w.Write([]byte(`{"status": "success", "data": [
+				{"id": 3, "label": "Also active", "paused": false}
+			], "pagination": {"page": 2, "next_page": null, "last_page": 2}}`))
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ds := provider.Provider().DataSourcesMap["census_syncs"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"workspace_id": "1",
+		"paused":       false,
+	})
+
+	if diags := ds.ReadContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("read failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	if got := d.Get("ids").([]interface{}); !reflect.DeepEqual(got, []interface{}{"1", "3"}) {
+		t.Errorf("ids = %v, want [1 3]", got)
+	}
+	syncs := d.Get("syncs").([]interface{})
+	if len(syncs) != 2 {
+		t.Fatalf("syncs has %d elements, want 2", len(syncs))
+	}
+	first := syncs[0].(map[string]interface{})
+	if first["label"] != "Active" {
+		t.Errorf("syncs[0].label = %v, want Active", first["label"])
+	}
+	source := first["source_attributes"].([]interface{})[0].(map[string]interface{})
+	if got := fmt.Sprint(source["connection_id"]); got != "10" {
+		t.Errorf("syncs[0].source_attributes.connection_id = %s, want 10", got)
+	}
+}
+
+func TestDataSourceWorkspaces_FiltersByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces" {
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"status": "success", "data": [
+			{"id": 2, "name": "Production", "organization_id": 7, "notification_emails": ["ops@example.com"]},
+			{"id": 1, "name": "Staging", "organization_id": 7}
+		], "pagination": {"page": 1, "next_page": null, "last_page": 1}}`))
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ds := provider.Provider().DataSourcesMap["census_workspaces"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"name_regex": "(?i)^prod",
+	})
+
+	if diags := ds.ReadContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("read failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	if got := d.Get("ids").([]interface{}); !reflect.DeepEqual(got, []interface{}{"2"}) {
+		t.Errorf("ids = %v, want [2]", got)
+	}
+	if got := d.Get("workspaces.0.notification_emails").(*schema.Set).List(); !reflect.DeepEqual(got, []interface{}{"ops@example.com"}) {
+		t.Errorf("workspaces.0.notification_emails = %v, want [ops@example.com]", got)
+	}
+}
@@ -0,0 +1,222 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	zcty "github.com/zclconf/go-cty/cty"
+	zctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/sutrolabs/terraform-provider-census/census/export"
+)
+
+// exportedWorkspace creates a workspace with a source, destination, dataset and two syncs, the second
+// triggered after the first and reading the dataset. It returns their states by name: workspace,
+// source, destination, dataset, contacts and leads.
+func exportedWorkspace(t *testing.T, p *schema.Provider, name string) map[string]*terraform.InstanceState {
+	t.Helper()
+
+	workspace := applyResource(t, p, "census_workspace", nil, map[string]interface{}{"name": name, "notification_emails": []interface{}{"data@example.com"}})
+	source := applyResource(t, p, "census_source", nil, map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "Warehouse",
+		"type":         "redshift",
+		"connection_config": map[string]interface{}{
+			"hostname": "redshift.example.com", "port": "5439", "database": "dev", "user": "census", "password": "hunter2",
+		},
+	})
+	destination := applyResource(t, p, "census_destination", nil, map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "CRM",
+		"type":         "salesforce",
+		"connection_config": map[string]interface{}{
+			"username": "census@example.com", "instance_url": "https://example.my.salesforce.com",
+			"client_id": "client", "jwt_signing_key": "private-key",
+		},
+	})
+	dataset := applyResource(t, p, "census_dataset", nil, map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "Active users",
+		"type":         "sql",
+		"source_id":    source.ID,
+		"query":        "SELECT id, email, first_name, last_name FROM users WHERE active",
+	})
+
+	contacts := fakeSyncConfig(workspace.ID, source.ID, destination.ID, "Users to contacts",
+		map[string]interface{}{"type": "constant", "constant": "Terraform", "to": "LeadSource"})
+	contacts["paused"] = false
+	first := applyResource(t, p, "census_sync", nil, contacts)
+
+	leads := fakeSyncConfig(workspace.ID, source.ID, destination.ID, "Users to leads")
+	leads["source_attributes"] = []interface{}{map[string]interface{}{
+		"connection_id": source.ID,
+		"object":        []interface{}{map[string]interface{}{"type": "dataset", "id": dataset.ID}},
+	}}
+	firstID, _ := strconv.Atoi(first.ID)
+	leads["run_mode"] = []interface{}{map[string]interface{}{
+		"type": "triggered",
+		"triggers": []interface{}{map[string]interface{}{
+			"sync_sequence": []interface{}{map[string]interface{}{"sync_id": firstID}},
+		}},
+	}}
+	return map[string]*terraform.InstanceState{
+		"workspace":   workspace,
+		"source":      source,
+		"destination": destination,
+		"dataset":     dataset,
+		"contacts":    first,
+		"leads":       applyResource(t, p, "census_sync", nil, leads),
+	}
+}
+
+func TestExport_GeneratesConfigThatMatchesImports(t *testing.T) {
+	p, _ := fakeAPIProvider(t)
+
+	marketing := exportedWorkspace(t, p, "Marketing")
+	workspace, source := marketing["workspace"], marketing["source"]
+	exportedWorkspace(t, p, "Not exported")
+	workspaceID, _ := strconv.Atoi(workspace.ID)
+
+	generated, err := export.Export(context.Background(), p, export.Options{WorkspaceIDs: []int{workspaceID}})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var all strings.Builder
+	for _, name := range export.FileNames() {
+		all.Write(generated[name])
+	}
+	for _, want := range []string{
+		`resource "census_source" "warehouse"`,
+		`workspace_id = census_workspace.marketing.id`,
+		`source_id    = census_source.warehouse.id`,
+		`connection_id = census_destination.crm.id`,
+		`id   = census_dataset.active_users.id`,
+		`sync_id = census_sync.users_to_contacts.id`,
+		`password = var.source_warehouse_password`,
+		`jwt_signing_key = var.destination_crm_jwt_signing_key`,
+		`variable "source_warehouse_password"`,
+		`sensitive   = true`,
+		`to = census_source.warehouse`,
+		`id = "` + workspace.ID + `:` + source.ID + `"`,
+	} {
+		if !strings.Contains(all.String(), want) {
+			t.Errorf("generated configuration does not contain %q:\n%s", want, all.String())
+		}
+	}
+	for _, unwanted := range []string{"hunter2", "private-key", "Not exported", "hmac-sha256"} {
+		if strings.Contains(all.String(), unwanted) {
+			t.Errorf("generated configuration contains %q", unwanted)
+		}
+	}
+
+	// Every generated resource plans no changes against the state its import block produces
+	imports := parseExported(t, generated, "imports.tf")
+	ids := map[string]string{}
+	resources := map[string]map[string]zcty.Value{}
+	for _, block := range imports.Blocks {
+		to := block.Body.Attributes["to"].Expr.(*hclsyntax.ScopeTraversalExpr).Traversal
+		id, _ := block.Body.Attributes["id"].Expr.Value(nil)
+		resourceType, name := to.RootName(), to[1].(hcl.TraverseAttr).Name
+		ids[resourceType+"."+name] = id.AsString()
+		if resources[resourceType] == nil {
+			resources[resourceType] = map[string]zcty.Value{}
+		}
+		parts := strings.Split(id.AsString(), ":")
+		resources[resourceType][name] = zcty.ObjectVal(map[string]zcty.Value{"id": zcty.StringVal(parts[len(parts)-1])})
+	}
+	if len(ids) != 6 {
+		t.Errorf("imports.tf has %d import blocks, want 6", len(ids))
+	}
+
+	variables := map[string]zcty.Value{}
+	for _, block := range parseExported(t, generated, "variables.tf").Blocks {
+		variables[block.Labels[0]] = zcty.StringVal("secret-" + block.Labels[0])
+	}
+	evalCtx := &hcl.EvalContext{Variables: map[string]zcty.Value{"var": zcty.ObjectVal(variables)}}
+	for resourceType, byName := range resources {
+		evalCtx.Variables[resourceType] = zcty.ObjectVal(byName)
+	}
+
+	for _, file := range []string{"workspaces.tf", "sources.tf", "destinations.tf", "datasets.tf", "syncs.tf"} {
+		for _, block := range parseExported(t, generated, file).Blocks {
+			resourceType, name := block.Labels[0], block.Labels[1]
+			t.Run(resourceType+"."+name, func(t *testing.T) {
+				state, err := importResource(t, p, resourceType, ids[resourceType+"."+name])
+				if err != nil {
+					t.Fatalf("import error = %v", err)
+				}
+				state = readResource(t, p, resourceType, state)
+				assertNoChanges(t, p, resourceType, state, evalBody(t, block.Body, evalCtx))
+			})
+		}
+	}
+}
+
+func TestExport_CommandRefusesToOverwrite(t *testing.T) {
+	_, server := fakeAPIProvider(t)
+	t.Setenv("CENSUS_PERSONAL_ACCESS_TOKEN", "fake-personal-access-token")
+	t.Setenv("CENSUS_BASE_URL", server.URL)
+	server.CreateWorkspace("Empty")
+
+	dir := t.TempDir()
+	var stdout, stderr strings.Builder
+	if code := export.Main(context.Background(), []string{"-output", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("export exited with %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "workspaces.tf") {
+		t.Errorf("export output = %q, want the written files", stdout.String())
+	}
+
+	stderr.Reset()
+	if code := export.Main(context.Background(), []string{"-output", dir}, &stdout, &stderr); code == 0 || !strings.Contains(stderr.String(), "-force") {
+		t.Errorf("second export exited with %d (%s), want it to refuse to overwrite", code, stderr.String())
+	}
+	if code := export.Main(context.Background(), []string{"-output", dir, "-force"}, &stdout, &stderr); code != 0 {
+		t.Errorf("export -force exited with %d: %s", code, stderr.String())
+	}
+}
+
+func parseExported(t *testing.T, generated map[string][]byte, name string) *hclsyntax.Body {
+	t.Helper()
+
+	f, diags := hclsyntax.ParseConfig(generated[name], name, hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("%s is not valid HCL: %s\n%s", name, diags.Error(), generated[name])
+	}
+	return f.Body.(*hclsyntax.Body)
+}
+
+// evalBody evaluates a generated resource body to the raw configuration Terraform would send
+func evalBody(t *testing.T, body *hclsyntax.Body, evalCtx *hcl.EvalContext) map[string]interface{} {
+	t.Helper()
+
+	raw := map[string]interface{}{}
+	for name, attr := range body.Attributes {
+		value, diags := attr.Expr.Value(evalCtx)
+		if diags.HasErrors() {
+			t.Fatalf("failed to evaluate %s: %s", name, diags.Error())
+		}
+		data, err := zctyjson.SimpleJSONValue{Value: value}.MarshalJSON()
+		if err != nil {
+			t.Fatalf("failed to encode %s: %v", name, err)
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("failed to decode %s: %v", name, err)
+		}
+		raw[name] = decoded
+	}
+	for _, block := range body.Blocks {
+		items, _ := raw[block.Type].([]interface{})
+		raw[block.Type] = append(items, evalBody(t, block.Body, evalCtx))
+	}
+	return raw
+}
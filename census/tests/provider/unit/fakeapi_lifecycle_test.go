@@ -0,0 +1,311 @@
+package unit_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+	"github.com/sutrolabs/terraform-provider-census/census/tests/fakeapi"
+)
+
+// fakeAPIProvider returns a provider configured through base_url against a new fake API server
+func fakeAPIProvider(t *testing.T) (*schema.Provider, *fakeapi.Server) {
+	t.Helper()
+
+	server := fakeapi.New()
+	t.Cleanup(server.Close)
+
+	p := provider.Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"personal_access_token": fakeapi.PersonalAccessToken,
+		"base_url":              server.URL,
+	}))
+	if diags.HasError() {
+		t.Fatalf("failed to configure provider: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	return p, server
+}
+
+// planResource plans raw against state with the provider's client, as Terraform does
+func planResource(t *testing.T, p *schema.Provider, name string, state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceState, *terraform.InstanceDiff, error) {
+	t.Helper()
+
+	r := p.ResourcesMap[name]
+	value, err := ctyJSON(t, raw, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("failed to build %s config: %v", name, err)
+	}
+	if state == nil {
+		state = &terraform.InstanceState{}
+	}
+	state.RawConfig = value
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(value, r.CoreConfigSchema()), p.Meta())
+	return state, diff, err
+}
+
+// applyResource plans raw against state, creates or updates the resource and reads it back
+func applyResource(t *testing.T, p *schema.Provider, name string, state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+
+	r := p.ResourcesMap[name]
+	state, diff, err := planResource(t, p, name, state, raw)
+	if err != nil {
+		t.Fatalf("%s plan failed: %v", name, err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("failed to apply %s diff: %v", name, err)
+	}
+
+	var diags diag.Diagnostics
+	if state.ID == "" {
+		diags = r.CreateContext(context.Background(), d, p.Meta())
+	} else {
+		diags = r.UpdateContext(context.Background(), d, p.Meta())
+	}
+	if diags.HasError() {
+		t.Fatalf("%s apply failed: %s: %s", name, diags[0].Summary, diags[0].Detail)
+	}
+	return readResource(t, p, name, d.State())
+}
+
+func readResource(t *testing.T, p *schema.Provider, name string, state *terraform.InstanceState) *terraform.InstanceState {
+	t.Helper()
+
+	r := p.ResourcesMap[name]
+	d := r.Data(state)
+	if diags := r.ReadContext(context.Background(), d, p.Meta()); diags.HasError() {
+		t.Fatalf("%s read failed: %s: %s", name, diags[0].Summary, diags[0].Detail)
+	}
+	return d.State()
+}
+
+// assertNoChanges plans raw against state and fails when anything would change
+func assertNoChanges(t *testing.T, p *schema.Provider, name string, state *terraform.InstanceState, raw map[string]interface{}) {
+	t.Helper()
+
+	_, diff, err := planResource(t, p, name, state, raw)
+	if err != nil {
+		t.Fatalf("%s plan failed: %v", name, err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		for key, attr := range diff.Attributes {
+			t.Errorf("%s plans %s: %q => %q", name, key, attr.Old, attr.New)
+		}
+	}
+}
+
+func deleteResource(t *testing.T, p *schema.Provider, name string, state *terraform.InstanceState) {
+	t.Helper()
+
+	r := p.ResourcesMap[name]
+	if diags := r.DeleteContext(context.Background(), r.Data(state), p.Meta()); diags.HasError() {
+		t.Fatalf("%s delete failed: %s: %s", name, diags[0].Summary, diags[0].Detail)
+	}
+}
+
+func fakeSyncConfig(workspaceID, sourceID, destinationID, label string, mappings ...map[string]interface{}) map[string]interface{} {
+	sourceConnection, _ := strconv.Atoi(sourceID)
+	destinationConnection, _ := strconv.Atoi(destinationID)
+	fieldMappings := []interface{}{
+		map[string]interface{}{"from": "email", "to": "Email", "is_primary_identifier": true},
+		map[string]interface{}{"from": "last_name", "to": "LastName"},
+	}
+	for _, mapping := range mappings {
+		fieldMappings = append(fieldMappings, mapping)
+	}
+
+	return map[string]interface{}{
+		"workspace_id": workspaceID,
+		"label":        label,
+		"operation":    "upsert",
+		"paused":       true,
+		"source_attributes": []interface{}{map[string]interface{}{
+			"connection_id": sourceConnection,
+			"object": []interface{}{map[string]interface{}{
+				"type": "table", "table_catalog": "dev", "table_schema": "public", "table_name": "users",
+			}},
+		}},
+		"destination_attributes": []interface{}{map[string]interface{}{
+			"connection_id": destinationConnection,
+			"object":        "Contact",
+		}},
+		"field_mapping": fieldMappings,
+		"run_mode": []interface{}{map[string]interface{}{
+			"type": "triggered",
+			"triggers": []interface{}{map[string]interface{}{
+				"schedule": []interface{}{map[string]interface{}{"frequency": "daily", "hour": 6, "minute": 0}},
+			}},
+		}},
+	}
+}
+
+func TestFakeAPI_ResourceLifecycle(t *testing.T) {
+	p, server := fakeAPIProvider(t)
+
+	workspaceConfig := map[string]interface{}{"name": "Offline", "notification_emails": []interface{}{"data@example.com"}}
+	workspace := applyResource(t, p, "census_workspace", nil, workspaceConfig)
+	assertNoChanges(t, p, "census_workspace", workspace, workspaceConfig)
+
+	sourceConfig := map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "Warehouse",
+		"type":         "redshift",
+		"connection_config": map[string]interface{}{
+			"hostname": "redshift.example.com", "port": "5439", "database": "dev", "user": "census", "password": "hunter2",
+		},
+	}
+	source := applyResource(t, p, "census_source", nil, sourceConfig)
+	assertNoChanges(t, p, "census_source", source, sourceConfig)
+	assertNoSecret(t, "source state", source.Attributes, "hunter2")
+
+	destinationConfig := map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "CRM",
+		"type":         "salesforce",
+		"connection_config": map[string]interface{}{
+			"username": "census@example.com", "instance_url": "https://example.my.salesforce.com",
+			"client_id": "client", "jwt_signing_key": "private-key", "domain": "login.salesforce.com",
+		},
+	}
+	destination := applyResource(t, p, "census_destination", nil, destinationConfig)
+	assertNoChanges(t, p, "census_destination", destination, destinationConfig)
+
+	datasetConfig := map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "Active users",
+		"type":         "sql",
+		"source_id":    source.ID,
+		"query":        "SELECT id, email, first_name, last_name FROM users WHERE active",
+	}
+	dataset := applyResource(t, p, "census_dataset", nil, datasetConfig)
+	assertNoChanges(t, p, "census_dataset", dataset, datasetConfig)
+	if got := dataset.Attributes["columns.#"]; got != "4" {
+		t.Errorf("dataset columns.# = %q, want 4", got)
+	}
+
+	syncConfig := fakeSyncConfig(workspace.ID, source.ID, destination.ID, "Users to contacts",
+		map[string]interface{}{"type": "constant", "constant": "Terraform", "to": "LeadSource"})
+	sync := applyResource(t, p, "census_sync", nil, syncConfig)
+	assertNoChanges(t, p, "census_sync", sync, syncConfig)
+	if got := sync.Attributes["run_mode.0.triggers.0.schedule.0.frequency"]; got != "daily" {
+		t.Errorf("sync schedule frequency = %q, want daily", got)
+	}
+
+	updatedSyncConfig := fakeSyncConfig(workspace.ID, source.ID, destination.ID, "Users to contacts (renamed)")
+	sync = applyResource(t, p, "census_sync", sync, updatedSyncConfig)
+	assertNoChanges(t, p, "census_sync", sync, updatedSyncConfig)
+	if got := sync.Attributes["field_mapping.#"]; got != "2" {
+		t.Errorf("updated sync field_mapping.# = %q, want 2", got)
+	}
+
+	run := applyResource(t, p, "census_sync_run", nil, map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"sync_id":      sync.ID,
+	})
+	if got := run.Attributes["status"]; got != "completed" {
+		t.Errorf("sync run status = %q, want completed", got)
+	}
+
+	deleteResource(t, p, "census_sync", sync)
+	deleteResource(t, p, "census_dataset", dataset)
+	deleteResource(t, p, "census_destination", destination)
+	deleteResource(t, p, "census_source", source)
+	if got := readResource(t, p, "census_sync", sync); got != nil && got.ID != "" {
+		t.Errorf("sync read after delete has ID %q, want it removed from state", got.ID)
+	}
+	deleteResource(t, p, "census_workspace", workspace)
+	if got := server.Count("workspaces"); got != 0 {
+		t.Errorf("%d workspaces left after delete, want 0", got)
+	}
+}
+
+func TestFakeAPI_SyncPlanValidatesAgainstCatalog(t *testing.T) {
+	p, server := fakeAPIProvider(t)
+
+	workspaceID, _ := server.CreateWorkspace("Offline")
+	workspace := strconv.Itoa(workspaceID)
+	source := applyResource(t, p, "census_source", nil, map[string]interface{}{
+		"workspace_id": workspace,
+		"name":         "Warehouse",
+		"type":         "redshift",
+		"connection_config": map[string]interface{}{
+			"hostname": "redshift.example.com", "port": "5439", "database": "dev", "user": "census", "password": "hunter2",
+		},
+	})
+	destination := applyResource(t, p, "census_destination", nil, map[string]interface{}{
+		"workspace_id": workspace,
+		"name":         "CRM",
+		"type":         "salesforce",
+		"connection_config": map[string]interface{}{
+			"username": "census@example.com", "instance_url": "https://example.my.salesforce.com",
+			"client_id": "client", "jwt_signing_key": "private-key",
+		},
+	})
+
+	config := fakeSyncConfig(workspace, source.ID, destination.ID, "Users to contacts",
+		map[string]interface{}{"from": "nickname", "to": "Nickname__c"})
+	_, _, err := planResource(t, p, "census_sync", nil, config)
+	if err == nil {
+		t.Fatal("plan succeeded, want unknown source column and destination field errors")
+	}
+	for _, want := range []string{"Nickname__c", "nickname"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("plan error = %v, want it to mention %q", err, want)
+		}
+	}
+}
+
+// The acceptance tests also read every object through its data source and import it by ID, so the
+// fake must answer those requests too
+func TestFakeAPI_DataSourcesAndImports(t *testing.T) {
+	p, _ := fakeAPIProvider(t)
+	objects := exportedWorkspace(t, p, "Offline")
+	workspace := objects["workspace"].ID
+
+	for _, tt := range []struct {
+		name   string
+		object string
+		key    string
+	}{
+		{"census_workspace", "workspace", "name"},
+		{"census_source", "source", "name"},
+		{"census_destination", "destination", "name"},
+		{"census_dataset", "dataset", "name"},
+		{"census_sync", "contacts", "label"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			state := objects[tt.object]
+			ds := p.DataSourcesMap[tt.name]
+			raw := map[string]interface{}{"id": state.ID}
+			importID := state.ID
+			if tt.name != "census_workspace" {
+				raw["workspace_id"] = workspace
+				importID = workspace + ":" + state.ID
+			}
+
+			d := schema.TestResourceDataRaw(t, ds.Schema, raw)
+			if diags := ds.ReadContext(context.Background(), d, p.Meta()); diags.HasError() {
+				t.Fatalf("data source read failed: %s: %s", diags[0].Summary, diags[0].Detail)
+			}
+			if got, want := d.Get(tt.key), state.Attributes[tt.key]; got != want {
+				t.Errorf("data source %s = %v, want %q", tt.key, got, want)
+			}
+
+			imported, err := importResource(t, p, tt.name, importID)
+			if err != nil {
+				t.Fatalf("import %q failed: %v", importID, err)
+			}
+			imported = readResource(t, p, tt.name, imported)
+			if imported.ID != state.ID || imported.Attributes[tt.key] != state.Attributes[tt.key] {
+				t.Errorf("imported %s %s = %q, want %s %q", tt.name, tt.key, imported.Attributes[tt.key], state.ID, state.Attributes[tt.key])
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package unit_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// importResource runs the importer of the named resource with importID
+func importResource(t *testing.T, p *schema.Provider, name, importID string) (*terraform.InstanceState, error) {
+	t.Helper()
+
+	r := p.ResourcesMap[name]
+	states, err := r.Importer.StateContext(context.Background(), r.Data(&terraform.InstanceState{ID: importID}), p.Meta())
+	if err != nil {
+		return nil, err
+	}
+	if len(states) != 1 {
+		t.Fatalf("%s import returned %d states, want 1", name, len(states))
+	}
+	return states[0].State(), nil
+}
+
+func TestImport_ByName(t *testing.T) {
+	p, server := fakeAPIProvider(t)
+
+	workspaceID, _ := server.CreateWorkspace("Migrated")
+	workspace := strconv.Itoa(workspaceID)
+	sourceConfig := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"workspace_id": workspace,
+			"name":         name,
+			"type":         "redshift",
+			"connection_config": map[string]interface{}{
+				"hostname": "redshift.example.com", "port": "5439", "database": "dev", "user": "census", "password": "hunter2",
+			},
+		}
+	}
+	source := applyResource(t, p, "census_source", nil, sourceConfig("Warehouse: prod"))
+	staging := applyResource(t, p, "census_source", nil, sourceConfig("Staging"))
+	stagingCopy := applyResource(t, p, "census_source", nil, sourceConfig("Staging"))
+	destination := applyResource(t, p, "census_destination", nil, map[string]interface{}{
+		"workspace_id": workspace,
+		"name":         "CRM",
+		"type":         "salesforce",
+		"connection_config": map[string]interface{}{
+			"username": "census@example.com", "instance_url": "https://example.my.salesforce.com",
+			"client_id": "client", "jwt_signing_key": "private-key",
+		},
+	})
+	dataset := applyResource(t, p, "census_dataset", nil, map[string]interface{}{
+		"workspace_id": workspace,
+		"name":         "Active users",
+		"type":         "sql",
+		"source_id":    source.ID,
+		"query":        "SELECT id, email FROM users WHERE active",
+	})
+	sync := applyResource(t, p, "census_sync", nil, fakeSyncConfig(workspace, source.ID, destination.ID, "Users to contacts"))
+
+	tests := []struct {
+		resource string
+		importID string
+		wantID   string
+	}{
+		{"census_source", workspace + ":" + source.ID, source.ID},
+		{"census_source", workspace + ":name=Warehouse: prod", source.ID},
+		{"census_destination", workspace + ":name=CRM", destination.ID},
+		{"census_dataset", workspace + ":name=Active users", dataset.ID},
+		{"census_sync", workspace + ":name=Users to contacts", sync.ID},
+		{"census_sync", workspace + ":" + sync.ID, sync.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.importID, func(t *testing.T) {
+			state, err := importResource(t, p, tt.resource, tt.importID)
+			if err != nil {
+				t.Fatalf("%s import error = %v", tt.resource, err)
+			}
+			if state.ID != tt.wantID || state.Attributes["workspace_id"] != workspace {
+				t.Errorf("%s import = ID %q, workspace_id %q, want %q, %q", tt.resource, state.ID, state.Attributes["workspace_id"], tt.wantID, workspace)
+			}
+		})
+	}
+
+	_, err := importResource(t, p, "census_source", workspace+":name=Staging")
+	wantIDs := fmt.Sprintf("(IDs %s, %s)", staging.ID, stagingCopy.ID)
+	if err == nil || !strings.Contains(err.Error(), wantIDs) || !strings.Contains(err.Error(), "workspace_id:source_id") {
+		t.Errorf("ambiguous import error = %v, want it to list %s", err, wantIDs)
+	}
+	if _, err := importResource(t, p, "census_sync", workspace+":name=Missing"); err == nil || !strings.Contains(err.Error(), `no sync with label "Missing" found`) {
+		t.Errorf("unknown label import error = %v, want not found", err)
+	}
+}
+
+func TestImport_InvalidFormats(t *testing.T) {
+	p, _ := fakeAPIProvider(t)
+
+	for _, importID := range []string{"123", "69962:", ":123", "69962:abc", "69962:123:4"} {
+		if _, err := importResource(t, p, "census_destination", importID); err == nil {
+			t.Errorf("import %q succeeded, want a format error", importID)
+		}
+	}
+}
+
+func TestImport_DefaultsAttributesTheAPIDoesNotReturn(t *testing.T) {
+	p, _ := fakeAPIProvider(t)
+
+	staging := exportedWorkspace(t, p, "Staging")
+	workspace := staging["workspace"].ID
+	tests := []struct {
+		resource  string
+		importID  string
+		attribute string
+		config    map[string]interface{}
+	}{
+		{"census_workspace", workspace, "return_workspace_api_key", map[string]interface{}{
+			"name": "Staging", "notification_emails": []interface{}{"data@example.com"},
+		}},
+		{"census_source", workspace + ":" + staging["source"].ID, "auto_refresh_tables", map[string]interface{}{
+			"workspace_id": workspace,
+			"name":         "Warehouse",
+			"type":         "redshift",
+			"connection_config": map[string]interface{}{
+				"hostname": "redshift.example.com", "port": "5439", "database": "dev", "user": "census", "password": "hunter2",
+			},
+		}},
+		{"census_destination", workspace + ":" + staging["destination"].ID, "auto_refresh_objects", map[string]interface{}{
+			"workspace_id": workspace,
+			"name":         "CRM",
+			"type":         "salesforce",
+			"connection_config": map[string]interface{}{
+				"username": "census@example.com", "instance_url": "https://example.my.salesforce.com",
+				"client_id": "client", "jwt_signing_key": "private-key",
+			},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			imported, err := importResource(t, p, tt.resource, tt.importID)
+			if err != nil {
+				t.Fatalf("%s import error = %v", tt.resource, err)
+			}
+			if got, ok := imported.Attributes[tt.attribute]; !ok || got != "false" {
+				t.Errorf("%s after import = %q (present: %v), want false", tt.attribute, got, ok)
+			}
+
+			// The imported default survives the read that follows an import, so leaving the attribute
+			// out of the configuration does not plan a change to it. Secrets the API does not return
+			// still plan changes, which is why only the attribute is checked.
+			imported = readResource(t, p, tt.resource, imported)
+			if got := imported.Attributes[tt.attribute]; got != "false" {
+				t.Errorf("%s after read = %q, want false", tt.attribute, got)
+			}
+			_, diff, err := planResource(t, p, tt.resource, imported, tt.config)
+			if err != nil {
+				t.Fatalf("%s plan failed: %v", tt.resource, err)
+			}
+			if diff != nil {
+				if attr, ok := diff.Attributes[tt.attribute]; ok {
+					t.Errorf("plan after import changes %s: %q => %q", tt.attribute, attr.Old, attr.New)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package unit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+func TestFindByName(t *testing.T) {
+	sources := []client.Source{
+		{ID: 1, Name: "Warehouse"},
+		{ID: 2, Name: "Staging"},
+		{ID: 3, Name: "Staging"},
+	}
+	nameOf := func(s client.Source) string { return s.Name }
+	idOf := func(s client.Source) int { return s.ID }
+
+	source, err := provider.FindByName(sources, "source", "name", "Warehouse", nameOf, idOf)
+	if err != nil {
+		t.Fatalf("FindByName() unexpected error: %v", err)
+	}
+	if source.ID != 1 {
+		t.Errorf("FindByName() returned source %d, want 1", source.ID)
+	}
+
+	if _, err := provider.FindByName(sources, "source", "name", "warehouse", nameOf, idOf); err == nil || !strings.Contains(err.Error(), `no source with name "warehouse" found`) {
+		t.Errorf("FindByName() error = %v, want a not found error (names are case sensitive)", err)
+	}
+
+	_, err = provider.FindByName(sources, "source", "name", "Staging", nameOf, idOf)
+	if err == nil || !strings.Contains(err.Error(), `2 sources with name "Staging" found (IDs 2, 3)`) {
+		t.Errorf("FindByName() error = %v, want an ambiguous match error listing both IDs", err)
+	}
+}
+
+func newLookupClient(t *testing.T) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1/api_key":
+			w.Write([]byte(`{"api_key": "workspace-token"}`))
+		case "/syncs":
+			if r.URL.Query().Get("page") == "1" {
+				w.Write([]byte(`{"status": "success", "data": [
+					{"id": 1, "label": "Users", "paused": false},
+					{"id": 2, "label": "Accounts", "paused": false}
+				], "pagination": {"page": 1, "next_page": 2, "last_page": 2}}`))
+				return
+			}
+			w.Write([]byte(`{"status": "success", "data": [
+				{"id": 3, "label": "Leads", "paused": true},
+				{"id": 4, "label": "Accounts", "paused": true}
+			], "pagination": {"page": 2, "next_page": null, "last_page": 2}}`))
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return apiClient
+}
+
+func TestDataSourceSync_LookupByLabel(t *testing.T) {
+	apiClient := newLookupClient(t)
+	ds := provider.Provider().DataSourcesMap["census_sync"]
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"workspace_id": "1",
+		"label":        "Leads",
+	})
+	if diags := ds.ReadContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("read failed: %s", diags[0].Summary)
+	}
+	if d.Id() != "3" {
+		t.Errorf("id = %q, want the sync from the second page (3)", d.Id())
+	}
+	if !d.Get("paused").(bool) {
+		t.Error("paused = false, want the attributes of the matched sync")
+	}
+
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"workspace_id": "1",
+		"label":        "Accounts",
+	})
+	diags := ds.ReadContext(context.Background(), d, apiClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "IDs 2, 4") {
+		t.Errorf("read diagnostics = %v, want an ambiguous label error listing IDs 2, 4", diags)
+	}
+}
@@ -16,3 +16,18 @@ func TestProvider(t *testing.T) {
 func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = provider.Provider()
 }
+
+func TestProvider_Regions(t *testing.T) {
+	validate := provider.Provider().Schema["region"].ValidateFunc
+	for _, region := range provider.Regions {
+		if _, errs := validate(region, "region"); len(errs) > 0 {
+			t.Errorf("region %q is rejected: %v", region, errs)
+		}
+	}
+	if _, errs := validate("ap", "region"); len(errs) == 0 {
+		t.Error("region \"ap\" is accepted, want an error")
+	}
+	if got, want := provider.RegionList(), "us or eu"; got != want {
+		t.Errorf("RegionList() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,204 @@
+package unit_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+// syncRunServer fakes the trigger, status and cancel endpoints for sync 5 / run 9.
+// The run reports "working" for the first workingPolls status requests and then finalRun.
+type syncRunServer struct {
+	*httptest.Server
+	polls     int32
+	cancelled int32
+}
+
+func newSyncRunServer(t *testing.T, workingPolls int32, finalRun string) *syncRunServer {
+	t.Helper()
+
+	s := &syncRunServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/workspaces/1/api_key":
+			w.Write([]byte(`{"api_key": "workspace-token"}`))
+		case r.URL.Path == "/syncs/5/trigger" && r.Method == http.MethodPost:
+			w.Write([]byte(`{"status": "success", "data": {"sync_run_id": 9}}`))
+		case r.URL.Path == "/sync_runs/9/cancel" && r.Method == http.MethodPost:
+			atomic.AddInt32(&s.cancelled, 1)
+			w.Write([]byte(`{"status": "success"}`))
+		case r.URL.Path == "/sync_runs/9":
+			if atomic.AddInt32(&s.polls, 1) <= workingPolls {
+				w.Write([]byte(`{"status": "success", "data": {"id": 9, "sync_id": 5, "status": "working"}}`))
+				return
+			}
+			fmt.Fprintf(w, `{"status": "success", "data": %s}`, finalRun)
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func createSyncRun(t *testing.T, ctx context.Context, server *httptest.Server, config map[string]interface{}) (*schema.ResourceData, error) {
+	t.Helper()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	raw := map[string]interface{}{
+		"workspace_id":  "1",
+		"sync_id":       "5",
+		"poll_interval": 1,
+	}
+	for key, value := range config {
+		raw[key] = value
+	}
+
+	r := provider.Provider().ResourcesMap["census_sync_run"]
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+
+	diags := r.CreateContext(ctx, d, apiClient)
+	if diags.HasError() {
+		return d, fmt.Errorf("%s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	return d, nil
+}
+
+func TestResourceSyncRun_WaitsForCompletion(t *testing.T) {
+	server := newSyncRunServer(t, 1, `{"id": 9, "sync_id": 5, "status": "completed", "records_processed": 200, "records_succeeded": 198, "records_failed": 2}`)
+
+	d, err := createSyncRun(t, context.Background(), server.Server, map[string]interface{}{
+		"failure_threshold_percent": 5.0,
+	})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	if d.Id() != "9" {
+		t.Errorf("id = %q, want %q", d.Id(), "9")
+	}
+	if got := d.Get("status").(string); got != "completed" {
+		t.Errorf("status = %q, want %q", got, "completed")
+	}
+	if got := d.Get("records_succeeded").(int); got != 198 {
+		t.Errorf("records_succeeded = %d, want 198", got)
+	}
+	if got := d.Get("failure_rate_percent").(float64); got != 1 {
+		t.Errorf("failure_rate_percent = %v, want 1", got)
+	}
+	if got := atomic.LoadInt32(&server.polls); got != 2 {
+		t.Errorf("run was polled %d times, want 2", got)
+	}
+}
+
+func TestResourceSyncRun_FailureThreshold(t *testing.T) {
+	server := newSyncRunServer(t, 0, `{"id": 9, "sync_id": 5, "status": "completed", "records_processed": 100, "records_failed": 10}`)
+
+	_, err := createSyncRun(t, context.Background(), server.Server, map[string]interface{}{
+		"failure_threshold_percent": 5.0,
+	})
+	if err == nil || !strings.Contains(err.Error(), "above the failure threshold") {
+		t.Errorf("create error = %v, want a failure threshold error", err)
+	}
+}
+
+func TestResourceSyncRun_FailedRun(t *testing.T) {
+	server := newSyncRunServer(t, 0, `{"id": 9, "sync_id": 5, "status": "failed", "error_message": "Destination rejected credentials"}`)
+
+	d, err := createSyncRun(t, context.Background(), server.Server, nil)
+	if err == nil || !strings.Contains(err.Error(), "Destination rejected credentials") {
+		t.Errorf("create error = %v, want the run's error message", err)
+	}
+	if d.Id() != "9" {
+		t.Errorf("id = %q, want the failed run to stay in state", d.Id())
+	}
+}
+
+func TestResourceSyncRun_CancelsOnTimeout(t *testing.T) {
+	server := newSyncRunServer(t, 1000, `{}`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+
+	_, err := createSyncRun(t, ctx, server.Server, nil)
+	if err == nil || !strings.Contains(err.Error(), "has been cancelled") {
+		t.Errorf("create error = %v, want a timeout error", err)
+	}
+	if got := atomic.LoadInt32(&server.cancelled); got != 1 {
+		t.Errorf("cancel was requested %d times, want 1", got)
+	}
+}
+
+func TestResourceSyncRun_WithoutWaiting(t *testing.T) {
+	server := newSyncRunServer(t, 1000, `{}`)
+
+	d, err := createSyncRun(t, context.Background(), server.Server, map[string]interface{}{
+		"wait_for_completion": false,
+	})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if got := d.Get("status").(string); got != "working" {
+		t.Errorf("status = %q, want %q", got, "working")
+	}
+	if got := atomic.LoadInt32(&server.polls); got != 1 {
+		t.Errorf("run was read %d times, want 1", got)
+	}
+}
+
+func TestSyncRunFailureRate(t *testing.T) {
+	tests := []struct {
+		name string
+		run  *client.SyncRun
+		want float64
+	}{
+		{"nil run", nil, 0},
+		{"no records", &client.SyncRun{}, 0},
+		{"some failures", &client.SyncRun{RecordsProcessed: 50, RecordsFailed: 5}, 10},
+		{"all failed", &client.SyncRun{RecordsProcessed: 3, RecordsFailed: 3}, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := provider.SyncRunFailureRate(tt.run); got != tt.want {
+				t.Errorf("SyncRunFailureRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTerminalSyncRunStatus(t *testing.T) {
+	for status, want := range map[string]bool{
+		"completed": true,
+		"failed":    true,
+		"cancelled": true,
+		"skipped":   true,
+		"Completed": true,
+		"working":   false,
+		"queued":    false,
+		"":          false,
+	} {
+		if got := provider.IsTerminalSyncRunStatus(status); got != want {
+			t.Errorf("IsTerminalSyncRunStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
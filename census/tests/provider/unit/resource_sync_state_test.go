@@ -0,0 +1,400 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+var orderedMappings = []interface{}{
+	map[string]interface{}{"from": "email", "to": "Email", "is_primary_identifier": true},
+	map[string]interface{}{"from": "first_name", "to": "FirstName"},
+	map[string]interface{}{"from": "last_name", "to": "LastName"},
+}
+
+var reorderedMappings = []interface{}{orderedMappings[2], orderedMappings[0], orderedMappings[1]}
+
+func TestResourceSync_FieldMappingOrderDoesNotDiff(t *testing.T) {
+	r := provider.Provider().ResourcesMap["census_sync"]
+	state := syncState(t, r, orderedMappings)
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(syncConfig("Contacts", false, reorderedMappings)), nil)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error: %v", err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("Diff() = %v, want no changes for reordered mappings", diff.Attributes)
+	}
+}
+
+func TestResourceSync_FieldMappingChangeDiffsOneMapping(t *testing.T) {
+	r := provider.Provider().ResourcesMap["census_sync"]
+	state := syncState(t, r, orderedMappings)
+
+	mappings := append([]interface{}{}, reorderedMappings...)
+	mappings[2] = map[string]interface{}{"from": "given_name", "to": "FirstName"}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(syncConfig("Contacts", false, mappings)), nil)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error: %v", err)
+	}
+	if diff == nil {
+		t.Fatal("Diff() = nil, want the FirstName mapping changed")
+	}
+
+	// A changed set is re-sent in full. The changed mapping is planned as the old element removed
+	// and the new one added, and the values of the other mappings are left alone.
+	changed := make(map[string]bool)
+	for key, attr := range diff.Attributes {
+		if key != "field_mapping.#" && attr.Old != attr.New {
+			changed[strings.Split(key, ".")[1]] = true
+		}
+	}
+	if len(changed) != 2 {
+		t.Errorf("Diff() changed %d mappings, want the old and new FirstName mapping", len(changed))
+	}
+	for code := range changed {
+		to := diff.Attributes["field_mapping."+code+".to"]
+		if to == nil || (to.Old != "FirstName" && to.New != "FirstName") {
+			t.Errorf("Diff() changed mapping %s (to = %v), want only FirstName", code, to)
+		}
+	}
+}
+
+// A mapping is hashed on every argument, so changing only a flag is still planned
+func TestResourceSync_FieldMappingFlagChangeDiffs(t *testing.T) {
+	r := provider.Provider().ResourcesMap["census_sync"]
+	state := syncState(t, r, orderedMappings)
+
+	mappings := append([]interface{}{}, orderedMappings...)
+	mappings[2] = map[string]interface{}{"from": "last_name", "to": "LastName", "preserve_values": true}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(syncConfig("Contacts", false, mappings)), nil)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error: %v", err)
+	}
+	if diff == nil {
+		t.Fatal("Diff() = nil, want the LastName mapping's preserve_values changed")
+	}
+
+	planned := false
+	for key, attr := range diff.Attributes {
+		if strings.HasSuffix(key, ".preserve_values") && attr.New == "true" {
+			planned = true
+			if to := diff.Attributes[strings.TrimSuffix(key, "preserve_values")+"to"]; to == nil || to.New != "LastName" {
+				t.Errorf("Diff() sets preserve_values on mapping to %v, want LastName", to)
+			}
+		}
+	}
+	if !planned {
+		t.Errorf("Diff() = %v, want preserve_values = true planned", diff.Attributes)
+	}
+}
+
+// sync020Config is the configuration the census_sync in testdata/census_sync_0.2.0.tfstate was applied
+// from, with its mappings in a different order
+func sync020Config() map[string]interface{} {
+	return map[string]interface{}{
+		"workspace_id": "69",
+		"label":        "Users to Salesforce contacts",
+		"operation":    "upsert",
+		"source_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 812,
+			"object": []interface{}{map[string]interface{}{
+				"type": "table", "table_catalog": "dev", "table_schema": "public", "table_name": "users",
+			}},
+		}},
+		"destination_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 934,
+			"object":        "Contact",
+		}},
+		"field_mapping": []interface{}{
+			map[string]interface{}{"to": "LeadSource", "type": "constant", "constant": "Census"},
+			map[string]interface{}{"from": "email", "to": "Email_Hash__c", "type": "hash"},
+			map[string]interface{}{"from": "last_name", "to": "LastName"},
+			map[string]interface{}{"from": "first_name", "to": "FirstName"},
+			map[string]interface{}{"from": "email", "to": "Email", "is_primary_identifier": true},
+		},
+		"run_mode": []interface{}{map[string]interface{}{
+			"type": "triggered",
+			"triggers": []interface{}{map[string]interface{}{
+				"schedule": []interface{}{map[string]interface{}{"frequency": "daily", "hour": 6, "minute": 30}},
+			}},
+		}},
+		"alert": []interface{}{map[string]interface{}{"type": "FailureAlertConfiguration"}},
+	}
+}
+
+// read020State returns the attributes of the census_sync in a state file written by provider 0.2.0
+func read020State(t *testing.T) json.RawMessage {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/census_sync_0.2.0.tfstate")
+	if err != nil {
+		t.Fatalf("failed to read state fixture: %v", err)
+	}
+	var state struct {
+		Resources []struct {
+			Instances []struct {
+				SchemaVersion int             `json:"schema_version"`
+				Attributes    json.RawMessage `json:"attributes"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("failed to decode state fixture: %v", err)
+	}
+	instance := state.Resources[0].Instances[0]
+	if instance.SchemaVersion != 0 {
+		t.Fatalf("state fixture schema_version = %d, want 0", instance.SchemaVersion)
+	}
+	return instance.Attributes
+}
+
+func TestResourceSync_StateUpgraderMatches020Schema(t *testing.T) {
+	r := provider.Provider().ResourcesMap["census_sync"]
+	if r.SchemaVersion != 1 || len(r.StateUpgraders) != 1 || r.StateUpgraders[0].Version != 0 {
+		t.Fatalf("census_sync SchemaVersion = %d with %d upgraders, want version 1 with one upgrader from 0", r.SchemaVersion, len(r.StateUpgraders))
+	}
+
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(read020State(t), &attributes); err != nil {
+		t.Fatalf("failed to decode state attributes: %v", err)
+	}
+	want := make([]string, 0, len(attributes))
+	for name := range attributes {
+		want = append(want, name)
+	}
+	got := make([]string, 0, len(attributes))
+	for name := range r.StateUpgraders[0].Type.AttributeTypes() {
+		got = append(got, name)
+	}
+	sort.Strings(want)
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("version 0 schema attributes =\n%v\nwant the 0.2.0 state attributes\n%v", got, want)
+	}
+	if _, err := ctyjson.Unmarshal(read020State(t), r.StateUpgraders[0].Type); err != nil {
+		t.Errorf("0.2.0 state does not decode with the version 0 schema: %v", err)
+	}
+}
+
+// Terraform upgrades state written by provider 0.2.0 through UpgradeResourceState, and the plan that
+// follows the refresh is empty even though the mappings are configured in a different order
+func TestResourceSync_StateUpgradeFrom020(t *testing.T) {
+	p := provider.Provider()
+	r := p.ResourcesMap["census_sync"]
+	ty := r.CoreConfigSchema().ImpliedType()
+
+	resp, err := p.GRPCProvider().UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "census_sync",
+		Version:  0,
+		RawState: &tfprotov5.RawState{JSON: read020State(t)},
+	})
+	if err != nil {
+		t.Fatalf("UpgradeResourceState() error = %v", err)
+	}
+	if len(resp.Diagnostics) > 0 {
+		t.Fatalf("UpgradeResourceState() diagnostics = %v", resp.Diagnostics)
+	}
+	value, err := msgpack.Unmarshal(resp.UpgradedState.MsgPack, ty)
+	if err != nil {
+		t.Fatalf("failed to decode upgraded state: %v", err)
+	}
+	state, err := r.ShimInstanceStateFromValue(value)
+	if err != nil {
+		t.Fatalf("failed to read upgraded state: %v", err)
+	}
+	if got := state.Attributes["field_mapping.#"]; got != "5" {
+		t.Errorf("upgraded field_mapping.# = %q, want 5", got)
+	}
+
+	// Set by the refresh that precedes every plan
+	d := r.Data(state)
+	if err := d.Set("next_runs", []string{}); err != nil {
+		t.Fatalf("failed to set next_runs: %v", err)
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(sync020Config()), nil)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error: %v", err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("Diff() after upgrade = %v, want no changes", diff.Attributes)
+	}
+}
+
+func TestLegacySyncMode(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	mode := func(schedule client.TriggerSchedule) *client.SyncMode {
+		return &client.SyncMode{Type: "triggered", Triggers: &client.SyncTriggers{Schedule: &schedule}}
+	}
+
+	tests := []struct {
+		name string
+		sync client.Sync
+		want *client.SyncMode
+	}{
+		{
+			name: "run mode",
+			sync: client.Sync{Mode: &client.SyncMode{Type: "live"}, ScheduleFrequency: "daily"},
+			want: &client.SyncMode{Type: "live"},
+		},
+		{
+			name: "flat weekly schedule",
+			sync: client.Sync{ScheduleFrequency: "weekly", ScheduleDay: intPtr(0), ScheduleHour: intPtr(6), ScheduleMinute: intPtr(15)},
+			want: mode(client.TriggerSchedule{Frequency: "weekly", Day: "Sunday", Hour: 6, Minute: 15}),
+		},
+		{
+			name: "flat hourly schedule ignores hour and day",
+			sync: client.Sync{ScheduleFrequency: "hourly", ScheduleDay: intPtr(3), ScheduleHour: intPtr(6), ScheduleMinute: intPtr(45)},
+			want: mode(client.TriggerSchedule{Frequency: "hourly", Minute: 45}),
+		},
+		{
+			name: "flat cron expression",
+			sync: client.Sync{ScheduleFrequency: "expression", CronExpression: "0 */2 * * *"},
+			want: mode(client.TriggerSchedule{Frequency: "expression", CronExpression: "0 */2 * * *"}),
+		},
+		{
+			name: "schedule object",
+			sync: client.Sync{Schedule: &client.SyncSchedule{Frequency: "daily", Hour: 23, Minute: 5, DayOfWeek: 2}},
+			want: mode(client.TriggerSchedule{Frequency: "daily", Hour: 23, Minute: 5}),
+		},
+		{name: "no schedule"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := provider.LegacySyncMode(&tt.sync); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LegacySyncMode() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceSync_ReadLegacySchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1/api_key":
+			w.Write([]byte(`{"api_key": "workspace-token"}`))
+		case "/syncs/1":
+			w.Write([]byte(`{"status": "success", "data": {"id": 1, "label": "Contacts", "operation": "upsert",
+				"schedule_frequency": "daily", "schedule_hour": 4, "schedule_minute": 20}}`))
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	r := provider.Provider().ResourcesMap["census_sync"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"workspace_id": "1"})
+	d.SetId("1")
+	if diags := r.ReadContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("read failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	schedule := d.Get("run_mode.0.triggers.0.schedule.0").(map[string]interface{})
+	if d.Get("run_mode.0.type") != "triggered" || schedule["frequency"] != "daily" || schedule["hour"] != 4 || schedule["minute"] != 20 {
+		t.Errorf("run_mode = %v, want a triggered daily schedule at 04:20", d.Get("run_mode"))
+	}
+	if runs := d.Get("next_runs").([]interface{}); len(runs) != 5 || !strings.HasSuffix(runs[0].(string), "T04:20:00Z") {
+		t.Errorf("next_runs = %v, want 5 runs at 04:20", runs)
+	}
+}
+
+func TestResourceSync_PlanRejectsDuplicateFieldMappingTargets(t *testing.T) {
+	r := provider.Provider().ResourcesMap["census_sync"]
+	mappings := append([]interface{}{}, orderedMappings...)
+	mappings = append(mappings, map[string]interface{}{"from": "given_name", "to": "FirstName"})
+
+	value, err := ctyJSON(t, syncConfig("Contacts", false, mappings), r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("failed to build config: %v", err)
+	}
+
+	// Terraform passes the raw config to CustomizeDiff through the prior state
+	state := &terraform.InstanceState{RawConfig: value}
+	_, err = r.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(value, r.CoreConfigSchema()), nil)
+	assertPlanPathError(t, err, cty.GetAttrPath("field_mapping"), `destination field "FirstName" is mapped more than once`)
+}
+
+func TestResourceSync_PlanReportsValidationErrorsAgainstAttribute(t *testing.T) {
+	server := provider.Provider().GRPCProvider()
+	ty := provider.Provider().ResourcesMap["census_sync"].CoreConfigSchema().ImpliedType()
+	mappings := append([]interface{}{}, orderedMappings...)
+	mappings = append(mappings, map[string]interface{}{"from": "given_name", "to": "FirstName"})
+	config, err := ctyJSON(t, syncConfig("Contacts", false, mappings), ty)
+	if err != nil {
+		t.Fatalf("failed to build config: %v", err)
+	}
+	dynamicValue := func(v cty.Value) *tfprotov5.DynamicValue {
+		b, err := msgpack.Marshal(v, ty)
+		if err != nil {
+			t.Fatalf("failed to encode value: %v", err)
+		}
+		return &tfprotov5.DynamicValue{MsgPack: b}
+	}
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         "census_sync",
+		PriorState:       dynamicValue(cty.NullVal(ty)),
+		ProposedNewState: dynamicValue(config),
+		Config:           dynamicValue(config),
+	})
+	if err != nil {
+		t.Fatalf("PlanResourceChange() error = %v", err)
+	}
+	if len(resp.Diagnostics) != 1 {
+		t.Fatalf("PlanResourceChange() diagnostics = %v, want one", resp.Diagnostics)
+	}
+	if want := tftypes.NewAttributePath().WithAttributeName("field_mapping"); !resp.Diagnostics[0].Attribute.Equal(want) {
+		t.Errorf("diagnostic attribute = %v, want %v", resp.Diagnostics[0].Attribute, want)
+	}
+}
+
+// syncState returns the state of an existing sync with the given mappings
+func syncState(t *testing.T, r *schema.Resource, mappings []interface{}) *terraform.InstanceState {
+	t.Helper()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, syncConfig("Contacts", false, mappings))
+	d.SetId("1")
+	// Set by the refresh that precedes every plan
+	if err := d.Set("next_runs", []string{}); err != nil {
+		t.Fatalf("failed to set next_runs: %v", err)
+	}
+	return d.State()
+}
+
+// ctyJSON converts a JSON-like map to a value of the given type, as Terraform decodes stored state
+func ctyJSON(t *testing.T, raw map[string]interface{}, ty cty.Type) (cty.Value, error) {
+	t.Helper()
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("failed to encode state: %v", err)
+	}
+	return ctyjson.Unmarshal(data, ty)
+}
@@ -0,0 +1,225 @@
+package unit_test
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+	"github.com/sutrolabs/terraform-provider-census/census/tests/fakeapi"
+)
+
+// everyMappingType has one field_mapping per mapping type, plus the optional per-mapping flags
+var everyMappingType = []interface{}{
+	map[string]interface{}{"from": "email", "to": "Email", "type": "direct", "is_primary_identifier": true},
+	map[string]interface{}{"from": "email", "to": "EmailHash__c", "type": "hash"},
+	map[string]interface{}{"from": "account_domain", "to": "AccountId", "lookup_object": "Account", "lookup_field": "Domain", "preserve_values": true},
+	map[string]interface{}{"to": "Source", "type": "constant", "constant": "census"},
+	map[string]interface{}{"to": "LastSyncRun", "type": "sync_metadata", "sync_metadata_key": "sync_run_id"},
+	map[string]interface{}{"to": "Segments", "type": "segment_membership", "segment_identify_by": "name"},
+	map[string]interface{}{"to": "Greeting", "type": "liquid_template", "liquid_template": `Hello {{ record["first_name"] }}`},
+	map[string]interface{}{"from": "tags", "to": "Tags__c", "generate_field": true, "array_field": true, "field_type": "string", "follow_source_type": true, "sync_null_values": false},
+}
+
+func syncConfig(label string, paused bool, mappings []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"workspace_id": "1",
+		"label":        label,
+		"operation":    "upsert",
+		"paused":       paused,
+		"source_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 10,
+			"object":        []interface{}{map[string]interface{}{"type": "model", "id": "5"}},
+		}},
+		"destination_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 20,
+			"object":        "Contact",
+		}},
+		"field_mapping": mappings,
+	}
+}
+
+// fakeSyncConnections creates a workspace with a source and a destination in the fake API, and returns
+// a function that builds syncConfig against them. The sync reads a model and writes to Member, which
+// the fake's catalogs do not describe, so the mappings are not checked against columns and fields.
+func fakeSyncConnections(t *testing.T, p *schema.Provider) func(label string, paused bool, mappings []interface{}) map[string]interface{} {
+	t.Helper()
+
+	workspace := applyResource(t, p, "census_workspace", nil, map[string]interface{}{"name": "Updates"})
+	source := applyResource(t, p, "census_source", nil, map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "Warehouse",
+		"type":         "redshift",
+		"connection_config": map[string]interface{}{
+			"hostname": "redshift.example.com", "port": "5439", "database": "dev", "user": "census", "password": "hunter2",
+		},
+	})
+	destination := applyResource(t, p, "census_destination", nil, map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"name":         "CRM",
+		"type":         "salesforce",
+		"connection_config": map[string]interface{}{
+			"username": "census@example.com", "instance_url": "https://example.my.salesforce.com",
+			"client_id": "client", "jwt_signing_key": "private-key",
+		},
+	})
+	sourceConnection, _ := strconv.Atoi(source.ID)
+	destinationConnection, _ := strconv.Atoi(destination.ID)
+
+	return func(label string, paused bool, mappings []interface{}) map[string]interface{} {
+		config := syncConfig(label, paused, mappings)
+		config["workspace_id"] = workspace.ID
+		config["source_attributes"].([]interface{})[0].(map[string]interface{})["connection_id"] = sourceConnection
+		config["destination_attributes"] = []interface{}{map[string]interface{}{
+			"connection_id": destinationConnection,
+			"object":        "Member",
+		}}
+		return config
+	}
+}
+
+func assertFieldMappings(t *testing.T, r *schema.Resource, state *terraform.InstanceState, want []interface{}) {
+	t.Helper()
+
+	expected := schema.TestResourceDataRaw(t, r.Schema, syncConfig("", false, want))
+	wantMappings := provider.ExpandFieldMappings(expected.Get("field_mapping").(*schema.Set).List())
+	gotMappings := provider.ExpandFieldMappings(r.Data(state).Get("field_mapping").(*schema.Set).List())
+	if !reflect.DeepEqual(gotMappings, wantMappings) {
+		t.Errorf("field_mapping after read =\n%+v\nwant\n%+v", gotMappings, wantMappings)
+	}
+}
+
+// lastPatch returns the body of the last PATCH request the fake API received
+func lastPatch(t *testing.T, server *fakeapi.Server) map[string]interface{} {
+	t.Helper()
+
+	patches := server.Requests("PATCH")
+	if len(patches) == 0 {
+		t.Fatal("no PATCH request was sent")
+	}
+	return patches[len(patches)-1].Body
+}
+
+func patchKeys(patch map[string]interface{}) []string {
+	keys := make([]string, 0, len(patch))
+	for key := range patch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestResourceSync_MappingsSurviveCreateUpdateRead(t *testing.T) {
+	p, server := fakeAPIProvider(t)
+	r := p.ResourcesMap["census_sync"]
+	config := fakeSyncConnections(t, p)
+
+	created := config("Contacts", false, everyMappingType)
+	sync := applyResource(t, p, "census_sync", nil, created)
+	assertFieldMappings(t, r, sync, everyMappingType)
+	assertNoChanges(t, p, "census_sync", sync, created)
+
+	// Hash mappings are column mappings with the hash operation
+	creates := server.Requests("POST")
+	mappings, _ := creates[len(creates)-1].Body["mappings"].([]interface{})
+	hashed := 0
+	for _, mapping := range mappings {
+		if m := mapping.(map[string]interface{}); m["operation"] == "hash" {
+			hashed++
+			if from := m["from"].(map[string]interface{}); from["type"] != "column" || from["data"] != "email" || m["to"] != "EmailHash__c" {
+				t.Errorf("hash mapping = %v, want a column mapping from email to EmailHash__c", m)
+			}
+		}
+	}
+	if hashed != 1 {
+		t.Errorf("create request has %d hash mappings, want 1: %v", hashed, mappings)
+	}
+
+	// Changing only label and paused sends only those attributes
+	paused := config("Contacts (paused)", true, everyMappingType)
+	sync = applyResource(t, p, "census_sync", sync, paused)
+	patch := lastPatch(t, server)
+	if got, want := patchKeys(patch), []string{"label", "paused"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first update sent %v, want %v", got, want)
+	}
+	if patch["paused"] != true {
+		t.Errorf("first update paused = %v, want true", patch["paused"])
+	}
+	assertFieldMappings(t, r, sync, everyMappingType)
+
+	// Changing mappings sends them in the mappings format, and unpausing sends paused = false
+	changed := append([]interface{}{}, everyMappingType...)
+	changed[3] = map[string]interface{}{"to": "Source", "type": "constant", "constant": "terraform"}
+	changed = append(changed, map[string]interface{}{"from": "phone", "to": "Phone", "type": "direct"})
+	unpaused := config("Contacts (paused)", false, changed)
+	sync = applyResource(t, p, "census_sync", sync, unpaused)
+
+	patch = lastPatch(t, server)
+	if got, want := patchKeys(patch), []string{"mappings", "paused"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("second update sent %v, want %v", got, want)
+	}
+	if patch["paused"] != false {
+		t.Errorf("second update paused = %v, want false", patch["paused"])
+	}
+	if mappings, ok := patch["mappings"].([]interface{}); !ok || len(mappings) != len(changed) {
+		t.Errorf("second update mappings = %v, want %d mappings", patch["mappings"], len(changed))
+	}
+	assertFieldMappings(t, r, sync, changed)
+	assertNoChanges(t, p, "census_sync", sync, unpaused)
+
+	if sync.Attributes["label"] != "Contacts (paused)" || sync.Attributes["paused"] != "false" {
+		t.Errorf("label, paused = %v, %v after update, want Contacts (paused), false", sync.Attributes["label"], sync.Attributes["paused"])
+	}
+}
+
+func TestResourceSync_UpdateClearsOptionalAttributes(t *testing.T) {
+	p, server := fakeAPIProvider(t)
+	config := fakeSyncConnections(t, p)
+
+	mappings := everyMappingType[:1]
+	full := config("Contacts", true, mappings)
+	full["operation"] = "mirror"
+	full["mirror_strategy"] = "sync_updates_and_deletes"
+	full["advanced_configuration"] = `{"batch_size": 100}`
+	full["high_water_mark_attribute"] = "updated_at"
+	full["alert"] = []interface{}{map[string]interface{}{"type": "FailureAlertConfiguration"}}
+	sync := applyResource(t, p, "census_sync", nil, full)
+	for key, want := range map[string]string{
+		"mirror_strategy":           "sync_updates_and_deletes",
+		"advanced_configuration":    `{"batch_size":100}`,
+		"high_water_mark_attribute": "updated_at",
+		"alert.#":                   "1",
+	} {
+		if got := sync.Attributes[key]; got != want {
+			t.Errorf("%s after create = %q, want %q", key, got, want)
+		}
+	}
+
+	// Removing them from the configuration sends empty values, since omitting them would change nothing.
+	// mirror_strategy is only cleared because the sync stops being a mirror sync.
+	cleared := config("Contacts", true, mappings)
+	sync = applyResource(t, p, "census_sync", sync, cleared)
+	patch := lastPatch(t, server)
+	for key, want := range map[string]interface{}{
+		"advanced_configuration":    map[string]interface{}{},
+		"high_water_mark_attribute": "",
+		"mirror_strategy":           "",
+		"alert_attributes":          []interface{}{},
+	} {
+		if got, ok := patch[key]; !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("update sent %s = %#v (present: %v), want %#v", key, got, ok, want)
+		}
+	}
+	for _, key := range []string{"advanced_configuration", "high_water_mark_attribute", "mirror_strategy"} {
+		if got := sync.Attributes[key]; got != "" {
+			t.Errorf("%s after update = %q, want it cleared", key, got)
+		}
+	}
+	if got := sync.Attributes["alert.#"]; got != "0" {
+		t.Errorf("alert.# after update = %q, want 0", got)
+	}
+	assertNoChanges(t, p, "census_sync", sync, cleared)
+}
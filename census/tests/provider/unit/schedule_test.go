@@ -0,0 +1,230 @@
+package unit_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+	"github.com/sutrolabs/terraform-provider-census/census/provider/cron"
+)
+
+func TestCronParse_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"* * * *", "must have 5 fields"},
+		{"60 * * * *", "value 60 in minute field is out of range 0-59"},
+		{"0 24 * * *", "value 24 in hour field is out of range 0-23"},
+		{"0 0 0 * *", "value 0 in day of month field is out of range 1-31"},
+		{"0 0 * 13 *", "value 13 in month field is out of range 1-12"},
+		{"0 0 * * 8", "value 8 in day of week field is out of range 0-7"},
+		{"*/0 * * * *", `invalid step "0"`},
+		{"0 10-5 * * *", "start is after end"},
+		{"0 0 * * funday", `invalid value "funday" in day of week field`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			err := cron.Validate(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate(%q) error = %v, want it to contain %q", tt.expr, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	from := time.Date(2024, time.January, 31, 10, 7, 30, 0, time.UTC) // a Wednesday
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"*/15 * * * *", []string{"2024-01-31T10:15:00Z", "2024-01-31T10:30:00Z", "2024-01-31T10:45:00Z"}},
+		{"0 9-17/4 * * mon-fri", []string{"2024-01-31T13:00:00Z", "2024-01-31T17:00:00Z", "2024-02-01T09:00:00Z"}},
+		{"30 2 29 feb *", []string{"2024-02-29T02:30:00Z", "2028-02-29T02:30:00Z"}},
+		{"@weekly", []string{"2024-02-04T00:00:00Z", "2024-02-11T00:00:00Z"}},
+		{"0 0 * * 7", []string{"2024-02-04T00:00:00Z"}},
+		// Day of month and day of week are ORed when both are restricted
+		{"0 12 1 * fri", []string{"2024-02-01T12:00:00Z", "2024-02-02T12:00:00Z", "2024-02-09T12:00:00Z"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := cron.Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.expr, err)
+			}
+			var got []string
+			for _, next := range schedule.NextN(from, len(tt.want)) {
+				got = append(got, next.Format(time.RFC3339))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("NextN(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_NextNeverFires(t *testing.T) {
+	schedule, err := cron.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if next := schedule.Next(time.Now()); !next.IsZero() {
+		t.Errorf("Next() = %v, want the zero time for February 30th", next)
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule client.TriggerSchedule
+		want     []string
+	}{
+		{name: "daily", schedule: client.TriggerSchedule{Frequency: "daily", Hour: 6, Minute: 30}},
+		{name: "weekly", schedule: client.TriggerSchedule{Frequency: "weekly", Day: "Monday", Hour: 6}},
+		{name: "expression", schedule: client.TriggerSchedule{Frequency: "expression", CronExpression: "0 */6 * * *"}},
+		{
+			name:     "weekly without day",
+			schedule: client.TriggerSchedule{Frequency: "weekly", Hour: 6},
+			want:     []string{`run_mode[0].triggers[0].schedule[0].day: day is required when frequency is "weekly"`},
+		},
+		{
+			name:     "expression without cron_expression",
+			schedule: client.TriggerSchedule{Frequency: "expression"},
+			want:     []string{`run_mode[0].triggers[0].schedule[0].cron_expression: cron_expression is required when frequency is "expression"`},
+		},
+		{
+			name:     "invalid cron_expression",
+			schedule: client.TriggerSchedule{Frequency: "expression", CronExpression: "0 25 * * *"},
+			want:     []string{`run_mode[0].triggers[0].schedule[0].cron_expression: invalid cron_expression: value 25 in hour field is out of range 0-23`},
+		},
+		{
+			name:     "expression with hour",
+			schedule: client.TriggerSchedule{Frequency: "expression", CronExpression: "@daily", Hour: 4},
+			want:     []string{`run_mode[0].triggers[0].schedule[0].hour: hour cannot be set when frequency is "expression"`},
+		},
+		{
+			name:     "cron_expression without expression frequency",
+			schedule: client.TriggerSchedule{Frequency: "daily", CronExpression: "0 6 * * *"},
+			want:     []string{`run_mode[0].triggers[0].schedule[0].cron_expression: cron_expression cannot be set when frequency is "daily"`},
+		},
+		{
+			name:     "hourly with day and hour",
+			schedule: client.TriggerSchedule{Frequency: "hourly", Day: "Monday", Hour: 3, Minute: 5},
+			want: []string{
+				`run_mode[0].triggers[0].schedule[0].day: day cannot be set when frequency is "hourly"`,
+				`run_mode[0].triggers[0].schedule[0].hour: hour cannot be set when frequency is "hourly"`,
+			},
+		},
+		{
+			name:     "never with minute",
+			schedule: client.TriggerSchedule{Frequency: "never", Minute: 10},
+			want:     []string{`run_mode[0].triggers[0].schedule[0].minute: minute cannot be set when frequency is "never"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(diagMessages(provider.ValidateSchedule(&tt.schedule)), "\n")
+			if got != strings.Join(tt.want, "\n") {
+				t.Errorf("errors = %q, want %q", got, strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestNextScheduledRuns(t *testing.T) {
+	from := time.Date(2024, time.January, 31, 10, 7, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule *client.TriggerSchedule
+		want     []string
+	}{
+		{
+			name:     "quarter hourly",
+			schedule: &client.TriggerSchedule{Frequency: "quarter_hourly", Minute: 20},
+			want:     []string{"2024-01-31T10:20:00Z", "2024-01-31T10:35:00Z"},
+		},
+		{
+			name:     "hourly",
+			schedule: &client.TriggerSchedule{Frequency: "hourly", Minute: 5},
+			want:     []string{"2024-01-31T11:05:00Z", "2024-01-31T12:05:00Z"},
+		},
+		{
+			name:     "daily at midnight as hour 24",
+			schedule: &client.TriggerSchedule{Frequency: "daily", Hour: 24},
+			want:     []string{"2024-02-01T00:00:00Z", "2024-02-02T00:00:00Z"},
+		},
+		{
+			name:     "weekly",
+			schedule: &client.TriggerSchedule{Frequency: "weekly", Day: "Friday", Hour: 8, Minute: 15},
+			want:     []string{"2024-02-02T08:15:00Z", "2024-02-09T08:15:00Z"},
+		},
+		{
+			name:     "expression",
+			schedule: &client.TriggerSchedule{Frequency: "expression", CronExpression: "0 0 1 * *"},
+			want:     []string{"2024-02-01T00:00:00Z", "2024-03-01T00:00:00Z"},
+		},
+		{name: "continuous", schedule: &client.TriggerSchedule{Frequency: "continuous"}},
+		{name: "no schedule"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := provider.NextScheduledRuns(tt.schedule, from, len(tt.want))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("NextScheduledRuns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceSync_PlanRejectsInvalidSchedule(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"workspace_id": "1",
+		"label":        "Nightly",
+		"operation":    "upsert",
+		"run_mode": []interface{}{map[string]interface{}{
+			"type": "triggered",
+			"triggers": []interface{}{map[string]interface{}{
+				"schedule": []interface{}{map[string]interface{}{
+					"frequency":       "expression",
+					"cron_expression": "0 0 * *",
+				}},
+			}},
+		}},
+		"source_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 10,
+			"object":        []interface{}{map[string]interface{}{"type": "model", "id": "5"}},
+		}},
+		"destination_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 20,
+			"object":        "Contact",
+		}},
+		"field_mapping": []interface{}{
+			map[string]interface{}{"from": "email", "to": "Email", "is_primary_identifier": true},
+		},
+	})
+
+	// The schedule is checked without calling the API, so no client is configured
+	r := provider.Provider().ResourcesMap["census_sync"]
+	_, err := r.Diff(context.Background(), nil, config, nil)
+	assertPlanPathError(t, err, cronExpressionPath, `invalid cron_expression: cron expression "0 0 * *" must have 5 fields`)
+
+	// terraform validate reports the malformed expression against the attribute before planning
+	diags := r.Validate(config)
+	if len(diags) != 1 || !diags[0].AttributePath.Equals(cronExpressionPath) || !strings.Contains(diags[0].Summary, "must have 5 fields") {
+		t.Errorf("Validate() = %v, want one error against %s", diagMessages(diags), provider.FormatAttributePath(cronExpressionPath))
+	}
+}
+
+var cronExpressionPath = cty.GetAttrPath("run_mode").IndexInt(0).GetAttr("triggers").IndexInt(0).GetAttr("schedule").IndexInt(0).GetAttr("cron_expression")
@@ -0,0 +1,211 @@
+package unit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+func sequencedSync(id int, label string, upstream int) client.Sync {
+	sync := client.Sync{ID: id, Label: label, Mode: &client.SyncMode{Type: "triggered"}}
+	if upstream != 0 {
+		sync.Mode.Triggers = &client.SyncTriggers{SyncSequence: &client.SyncSequenceTrigger{SyncId: upstream}}
+	}
+	return sync
+}
+
+// testSyncPipeline is 1 -> 2 -> 3 and 1 -> 4, with 5 paused and 6 waiting for a deleted sync 99
+var testSyncPipeline = []client.Sync{
+	sequencedSync(4, "Accounts", 1),
+	sequencedSync(3, "Opportunities", 2),
+	sequencedSync(2, "Contacts", 1),
+	sequencedSync(1, "Users", 0),
+	{ID: 5, Label: "Paused", Paused: true},
+	sequencedSync(6, "Orphan", 99),
+}
+
+func TestSyncGraph_TopologicalOrder(t *testing.T) {
+	graph := provider.BuildSyncGraph(testSyncPipeline)
+
+	order, err := graph.TopologicalOrder()
+	if err != nil {
+		t.Fatalf("TopologicalOrder() unexpected error: %v", err)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(order, want) {
+		t.Errorf("TopologicalOrder() = %v, want %v", order, want)
+	}
+
+	if got, want := graph.DanglingEdges(), []provider.SyncGraphEdge{{From: 99, To: 6}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DanglingEdges() = %v, want %v", got, want)
+	}
+
+	graph.SetUpstream(1, 3)
+	if _, err := graph.TopologicalOrder(); err == nil || !strings.Contains(err.Error(), "cycle: 1 -> 2 -> 3 -> 1") {
+		t.Errorf("TopologicalOrder() error = %v, want the 1 -> 2 -> 3 -> 1 cycle", err)
+	}
+}
+
+func TestValidateSyncSequenceTrigger(t *testing.T) {
+	tests := []struct {
+		name     string
+		syncID   int
+		upstream int
+		want     string
+	}{
+		{name: "new sync after an active sync", upstream: 3},
+		{name: "existing sync moved to another upstream", syncID: 4, upstream: 3},
+		{
+			name:     "cycle",
+			syncID:   1,
+			upstream: 3,
+			want:     "run_mode[0].triggers[0].sync_sequence[0].sync_id: triggering after sync 3 would create a cycle: 1 -> 2 -> 3 -> 1",
+		},
+		{
+			name:     "self reference",
+			syncID:   2,
+			upstream: 2,
+			want:     "run_mode[0].triggers[0].sync_sequence[0].sync_id: triggering after sync 2 would create a cycle: 2 -> 2",
+		},
+		{
+			name:     "deleted sync",
+			upstream: 99,
+			want:     "run_mode[0].triggers[0].sync_sequence[0].sync_id: sync 99 does not exist in this workspace",
+		},
+		{
+			name:     "paused sync",
+			upstream: 5,
+			want:     `run_mode[0].triggers[0].sync_sequence[0].sync_id: sync 5 ("Paused") is paused, so this sync would never be triggered`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(diagMessages(provider.ValidateSyncSequenceTrigger(provider.BuildSyncGraph(testSyncPipeline), tt.syncID, tt.upstream)), "\n")
+			if got != tt.want {
+				t.Errorf("errors = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func newSyncGraphTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1/api_key":
+			w.Write([]byte(`{"api_key": "workspace-token"}`))
+		case "/syncs":
+			w.Write([]byte(`{"status": "success", "data": [
+				{"id": 1, "label": "Users", "mode": {"type": "triggered", "triggers": {"schedule": {"frequency": "daily"}}}},
+				{"id": 2, "label": "Contacts", "mode": {"type": "triggered", "triggers": {"sync_sequence": {"sync_id": 1}}}},
+				{"id": 3, "label": "Opportunities", "mode": {"type": "triggered", "triggers": {"sync_sequence": {"sync_id": 2}}}},
+				{"id": 4, "label": "Orphan", "mode": {"type": "triggered", "triggers": {"sync_sequence": {"sync_id": 99}}}}
+			], "pagination": {"page": 1, "next_page": null, "last_page": 1}}`))
+		case "/destinations/20/objects":
+			w.Write([]byte(`{"status": "success", "data": []}`))
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestDataSourceSyncGraph(t *testing.T) {
+	server := newSyncGraphTestServer(t)
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ds := provider.Provider().DataSourcesMap["census_sync_graph"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"workspace_id": "1"})
+	if diags := ds.ReadContext(context.Background(), d, apiClient); diags.HasError() {
+		t.Fatalf("read failed: %s: %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	if got := d.Get("topological_order").([]interface{}); !reflect.DeepEqual(got, []interface{}{"1", "2", "3", "4"}) {
+		t.Errorf("topological_order = %v, want [1 2 3 4]", got)
+	}
+	wantEdges := []interface{}{
+		map[string]interface{}{"from": "1", "to": "2"},
+		map[string]interface{}{"from": "2", "to": "3"},
+	}
+	if got := d.Get("edges").([]interface{}); !reflect.DeepEqual(got, wantEdges) {
+		t.Errorf("edges = %v, want %v", got, wantEdges)
+	}
+	if got := d.Get("dangling_edges").([]interface{}); !reflect.DeepEqual(got, []interface{}{map[string]interface{}{"from": "99", "to": "4"}}) {
+		t.Errorf("dangling_edges = %v, want 99 -> 4", got)
+	}
+	first := d.Get("nodes.0").(map[string]interface{})
+	if first["label"] != "Users" || first["upstream_sync_id"] != "" || !reflect.DeepEqual(first["downstream_sync_ids"], []interface{}{"2"}) {
+		t.Errorf("nodes[0] = %v, want Users triggering sync 2", first)
+	}
+}
+
+func TestResourceSync_PlanRejectsSyncSequenceCycle(t *testing.T) {
+	server := newSyncGraphTestServer(t)
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":                               "1",
+			"workspace_id":                     "1",
+			"run_mode.#":                       "1",
+			"run_mode.0.type":                  "triggered",
+			"run_mode.0.triggers.#":            "1",
+			"run_mode.0.triggers.0.schedule.#": "1",
+			"run_mode.0.triggers.0.schedule.0.frequency": "daily",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"workspace_id": "1",
+		"label":        "Users",
+		"operation":    "upsert",
+		"run_mode": []interface{}{map[string]interface{}{
+			"type": "triggered",
+			"triggers": []interface{}{map[string]interface{}{
+				"sync_sequence": []interface{}{map[string]interface{}{"sync_id": 3}},
+			}},
+		}},
+		"source_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 10,
+			"object":        []interface{}{map[string]interface{}{"type": "model", "id": "5"}},
+		}},
+		"destination_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 20,
+			"object":        "Contact",
+		}},
+		"field_mapping": []interface{}{
+			map[string]interface{}{"from": "email", "to": "Email", "is_primary_identifier": true},
+		},
+	})
+
+	r := provider.Provider().ResourcesMap["census_sync"]
+	_, err = r.Diff(context.Background(), state, config, apiClient)
+	assertPlanPathError(t, err, cty.GetAttrPath("run_mode").IndexInt(0).GetAttr("triggers").IndexInt(0).GetAttr("sync_sequence").IndexInt(0).GetAttr("sync_id"),
+		"triggering after sync 3 would create a cycle: 1 -> 2 -> 3 -> 1")
+}
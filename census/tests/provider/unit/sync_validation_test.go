@@ -0,0 +1,296 @@
+package unit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+var testDestinationObjects = []client.DestinationObject{
+	{ID: "Contact", Name: "Contact", Fields: []client.DestinationField{
+		{ID: "Email", Name: "Email", Required: true},
+		{ID: "LastName", Name: "Last Name", Required: true},
+		{ID: "AccountId", Name: "Account ID"},
+	}},
+	{ID: "Account", Name: "Account", Fields: []client.DestinationField{
+		{ID: "Id", Name: "Account ID"},
+		{ID: "Domain", Name: "Domain"},
+	}},
+}
+
+// diagMessages renders diagnostics as "path: summary" lines
+func diagMessages(diags diag.Diagnostics) []string {
+	lines := make([]string, 0, len(diags))
+	for _, d := range diags {
+		lines = append(lines, provider.FormatAttributePath(d.AttributePath)+": "+d.Summary)
+	}
+	return lines
+}
+
+// assertPlanPathError checks that a plan failed with a cty.PathError, which the SDK reports against the
+// attribute at path, and that its message contains each of want
+func assertPlanPathError(t *testing.T, err error, path cty.Path, want ...string) {
+	t.Helper()
+
+	pathErr, ok := err.(cty.PathError)
+	if !ok {
+		t.Fatalf("Diff() error = %#v, want a cty.PathError", err)
+	}
+	if !pathErr.Path.Equals(path) {
+		t.Errorf("Diff() error path = %s, want %s", provider.FormatAttributePath(pathErr.Path), provider.FormatAttributePath(path))
+	}
+	for _, w := range want {
+		if !strings.Contains(err.Error(), w) {
+			t.Errorf("Diff() error = %v, want it to contain %q", err, w)
+		}
+	}
+}
+
+func TestValidateFieldMappingsAgainstDestination(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []client.FieldMapping
+		want     []string
+	}{
+		{
+			name: "valid mappings",
+			mappings: []client.FieldMapping{
+				{To: "email"},
+				{To: "LastName"},
+				{To: "AccountId", LookupObject: "Account", LookupField: "Domain"},
+			},
+		},
+		{
+			name: "unknown to field",
+			mappings: []client.FieldMapping{
+				{To: "Email"},
+				{To: "LastName"},
+				{To: "Emial"},
+			},
+			want: []string{`field_mapping: destination object "Contact" has no field "Emial"`},
+		},
+		{
+			name: "generated fields are not checked",
+			mappings: []client.FieldMapping{
+				{To: "Email"},
+				{To: "LastName"},
+				{To: "Score__c", GenerateField: true},
+			},
+		},
+		{
+			name:     "required field not mapped",
+			mappings: []client.FieldMapping{{To: "Email"}},
+			want:     []string{`field_mapping: required field "LastName" of destination object "Contact" is not mapped`},
+		},
+		{
+			name:     "unknown to skips the required check",
+			mappings: []client.FieldMapping{{To: "Email"}, {To: ""}},
+		},
+		{
+			name: "bad lookup pair",
+			mappings: []client.FieldMapping{
+				{To: "Email"},
+				{To: "LastName", LookupObject: "Opportunity", LookupField: "Id"},
+				{To: "AccountId", LookupObject: "Account", LookupField: "Website"},
+			},
+			want: []string{
+				`field_mapping: mapping to "LastName": lookup_object "Opportunity" does not exist in the destination`,
+				`field_mapping: mapping to "AccountId": lookup_field "Website" does not exist on destination object "Account"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := diagMessages(provider.ValidateFieldMappingsAgainstDestination(testDestinationObjects, "Contact", tt.mappings))
+			if len(lines) != len(tt.want) {
+				t.Fatalf("got %d errors, want %d:\n%s", len(lines), len(tt.want), strings.Join(lines, "\n"))
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(lines[i], want) {
+					t.Errorf("error %d = %q, want prefix %q", i, lines[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFieldMappingsAgainstDestination_UnknownObject(t *testing.T) {
+	diags := provider.ValidateFieldMappingsAgainstDestination(testDestinationObjects, "Lead", []client.FieldMapping{{To: "Anything"}})
+	if len(diags) != 0 {
+		t.Errorf("expected objects missing from the catalog to be skipped, got %v", diagMessages(diags))
+	}
+}
+
+func TestResourceSync_PlanRejectsUnknownDestinationField(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1/api_key":
+			w.Write([]byte(`{"api_key": "workspace-token"}`))
+		case "/sources/10/tables":
+			w.Write([]byte(`{"status": "success", "data": [{"id": 3, "table_schema": "public", "table_name": "users"}], "pagination": {"page": 1, "next_page": null}}`))
+		case "/sources/10/tables/3/columns":
+			w.Write([]byte(`{"status": "success", "data": [{"name": "email", "data_type": "varchar"}, {"name": "name", "data_type": "varchar"}], "pagination": {"page": 1, "next_page": null}}`))
+		case "/destinations/20/objects":
+			w.Write([]byte(`{"status": "success", "data": [
+				{"id": "Contact", "name": "Contact", "fields": [
+					{"id": "Email", "name": "Email", "required": true}
+				]}
+			]}`))
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"workspace_id": "1",
+		"label":        "Users to Salesforce",
+		"operation":    "upsert",
+		"source_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 10,
+			"object":        []interface{}{map[string]interface{}{"type": "table", "table_name": "users"}},
+		}},
+		"destination_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 20,
+			"object":        "Contact",
+		}},
+		"field_mapping": []interface{}{
+			map[string]interface{}{"from": "email", "to": "Email", "is_primary_identifier": true},
+			map[string]interface{}{"from": "name", "to": "FullName"},
+		},
+	})
+
+	r := provider.Provider().ResourcesMap["census_sync"]
+	_, err = r.Diff(context.Background(), nil, config, apiClient)
+	assertPlanPathError(t, err, cty.GetAttrPath("field_mapping"), `destination object "Contact" has no field "FullName"`)
+}
+
+func TestValidateSourceColumns(t *testing.T) {
+	columns := []client.SourceColumn{
+		{Name: "EMAIL", DataType: "VARCHAR"},
+		{Name: "UPDATED_AT", DataType: "TIMESTAMP_NTZ"},
+		{Name: "SIGNUP_DATE", DataType: "DATE"},
+	}
+
+	tests := []struct {
+		name          string
+		mappings      []client.FieldMapping
+		highWaterMark string
+		want          []string
+	}{
+		{
+			name: "columns match ignoring case",
+			mappings: []client.FieldMapping{
+				{From: "email", To: "Email"},
+				{From: "updated_at", To: "Updated", Type: "hash"},
+			},
+			highWaterMark: "updated_at",
+		},
+		{
+			name: "non column mappings are skipped",
+			mappings: []client.FieldMapping{
+				{To: "Source", Type: "constant", Constant: "census"},
+				{To: "Run", Type: "sync_metadata", SyncMetadataKey: "sync_run_id"},
+			},
+		},
+		{
+			name:     "missing column",
+			mappings: []client.FieldMapping{{From: "EMAIL", To: "Email"}, {From: "phone", To: "Phone"}},
+			want:     []string{`field_mapping: mapping to "Phone": source dataset 4 has no column "phone"`},
+		},
+		{
+			name:          "high water mark must exist",
+			highWaterMark: "modified_at",
+			want:          []string{`high_water_mark_attribute: source dataset 4 has no column "modified_at"`},
+		},
+		{
+			name:          "high water mark must be a timestamp",
+			highWaterMark: "signup_date",
+			want:          []string{`high_water_mark_attribute: column "signup_date" has type "DATE", a timestamp column is required`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(diagMessages(provider.ValidateSourceColumns(columns, "dataset 4", tt.mappings, tt.highWaterMark)), "\n")
+			if got != strings.Join(tt.want, "\n") {
+				t.Errorf("errors = %q, want %q", got, strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestResourceSync_PlanRejectsBadHighWaterMark(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1/api_key":
+			w.Write([]byte(`{"api_key": "workspace-token"}`))
+		case "/datasets/4":
+			w.Write([]byte(`{"status": "success", "data": {"id": 4, "name": "events", "type": "sql", "columns": [
+				{"name": "event_id", "data_type": "string"},
+				{"name": "occurred_on", "data_type": "date"}
+			]}}`))
+		case "/destinations/20/objects":
+			w.Write([]byte(`{"status": "success", "data": []}`))
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(&client.Config{
+		PersonalAccessToken: "test-token",
+		BaseURL:             server.URL,
+		HTTPClient:          server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"workspace_id":              "1",
+		"label":                     "Events",
+		"operation":                 "append",
+		"high_water_mark_attribute": "occurred_on",
+		"source_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 10,
+			"object":        []interface{}{map[string]interface{}{"type": "dataset", "id": "4"}},
+		}},
+		"destination_attributes": []interface{}{map[string]interface{}{
+			"connection_id": 20,
+			"object":        "events",
+		}},
+		"field_mapping": []interface{}{
+			map[string]interface{}{"from": "event_id", "to": "id", "is_primary_identifier": true},
+			map[string]interface{}{"from": "evnet_name", "to": "name"},
+		},
+	})
+
+	r := provider.Provider().ResourcesMap["census_sync"]
+	_, err = r.Diff(context.Background(), nil, config, apiClient)
+	// The first problem carries the path; later ones are listed in the message with theirs
+	assertPlanPathError(t, err, cty.GetAttrPath("field_mapping"),
+		`mapping to "name": source dataset 4 has no column "evnet_name"`,
+		"\nhigh_water_mark_attribute: column \"occurred_on\" has type \"date\", a timestamp column is required",
+	)
+}
@@ -0,0 +1,139 @@
+package unit_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+	"github.com/sutrolabs/terraform-provider-census/census/provider"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	notFound := &client.APIError{StatusCode: 404, Message: "Not found"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"not found", notFound, true},
+		{"wrapped not found", fmt.Errorf("failed to get sync: %w", notFound), true},
+		{"server error", &client.APIError{StatusCode: 500}, false},
+		{"other error", fmt.Errorf("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := provider.IsNotFoundError(tt.err); got != tt.want {
+				t.Errorf("IsNotFoundError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	p := provider.Provider()
+	sourceSchema := p.ResourcesMap["census_source"].Schema
+	syncSchema := p.ResourcesMap["census_sync"].Schema
+
+	sourceFields := map[string]string{"connection.credentials": "connection_config", "connection.name": "name"}
+	syncFields := map[string]string{"mappings": "field_mapping"}
+	listSchema := map[string]*schema.Schema{
+		"field_mapping": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"to": {Type: schema.TypeString, Optional: true},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		field          string
+		resourceSchema map[string]*schema.Schema
+		fields         map[string]string
+		want           cty.Path
+	}{
+		{
+			name:           "top level attribute",
+			field:          "label",
+			resourceSchema: syncSchema,
+			want:           cty.GetAttrPath("label"),
+		},
+		{
+			name:           "renamed map key",
+			field:          "connection.credentials.password",
+			resourceSchema: sourceSchema,
+			fields:         sourceFields,
+			want:           cty.GetAttrPath("connection_config").IndexString("password"),
+		},
+		{
+			name:           "renamed attribute",
+			field:          "connection.name",
+			resourceSchema: sourceSchema,
+			fields:         sourceFields,
+			want:           cty.GetAttrPath("name"),
+		},
+		{
+			name:           "list element attribute",
+			field:          "mappings[1].to",
+			resourceSchema: listSchema,
+			fields:         syncFields,
+			want:           cty.GetAttrPath("field_mapping").IndexInt(1).GetAttr("to"),
+		},
+		{
+			// Set elements have no position, so the error is reported on the set
+			name:           "set element attribute",
+			field:          "mappings[1].to",
+			resourceSchema: syncSchema,
+			fields:         syncFields,
+			want:           cty.GetAttrPath("field_mapping"),
+		},
+		{
+			name:           "single nested block",
+			field:          "source_attributes.connection_id",
+			resourceSchema: syncSchema,
+			want:           cty.GetAttrPath("source_attributes").IndexInt(0).GetAttr("connection_id"),
+		},
+		{
+			name:           "unknown field",
+			field:          "sync_engine",
+			resourceSchema: sourceSchema,
+			fields:         sourceFields,
+			want:           nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("failed to create: %w", &client.ValidationError{
+				APIError:    &client.APIError{StatusCode: 422, Message: "Validation failed"},
+				FieldErrors: map[string][]string{tt.field: {"is invalid"}},
+			})
+
+			diags := provider.APIErrorDiagnostics(err, tt.resourceSchema, tt.fields)
+			if len(diags) != 1 {
+				t.Fatalf("APIErrorDiagnostics() returned %d diagnostics, want 1", len(diags))
+			}
+			if !diags[0].AttributePath.Equals(tt.want) {
+				t.Errorf("AttributePath = %#v, want %#v", diags[0].AttributePath, tt.want)
+			}
+			if diags[0].Detail == "" {
+				t.Error("diagnostic has no detail")
+			}
+		})
+	}
+}
+
+func TestAPIErrorDiagnostics_NonValidationError(t *testing.T) {
+	diags := provider.APIErrorDiagnostics(&client.APIError{StatusCode: 500, Message: "boom"}, nil, nil)
+	if len(diags) != 1 || diags[0].AttributePath != nil {
+		t.Fatalf("APIErrorDiagnostics() = %#v, want a single diagnostic without an attribute path", diags)
+	}
+	if diags[0].Summary != "Census API error (status 500): boom" {
+		t.Errorf("Summary = %q, want the API error message", diags[0].Summary)
+	}
+}
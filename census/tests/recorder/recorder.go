@@ -0,0 +1,311 @@
+// Package recorder records Census API interactions to cassette files and replays them, so tests that
+// were recorded once against the real API can run without network access. A Recorder is an
+// http.RoundTripper for client.Config.HTTPClient. Tokens and credentials are scrubbed before a
+// cassette is written.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sutrolabs/terraform-provider-census/census/client"
+)
+
+// ModeEnvVar selects the Mode of recorders created with ModeFromEnv
+const ModeEnvVar = "CENSUS_TEST_RECORDER"
+
+// Mode is how a Recorder handles requests
+type Mode string
+
+const (
+	// ModePassthrough sends requests to the API without recording them
+	ModePassthrough Mode = "passthrough"
+	// ModeRecord sends requests to the API and saves the interactions to the cassette on Stop
+	ModeRecord Mode = "record"
+	// ModeReplay answers requests from the cassette without using the network
+	ModeReplay Mode = "replay"
+)
+
+// ModeFromEnv returns the mode set in ModeEnvVar, or ModePassthrough when it is unset
+func ModeFromEnv() (Mode, error) {
+	switch mode := Mode(os.Getenv(ModeEnvVar)); mode {
+	case "":
+		return ModePassthrough, nil
+	case ModePassthrough, ModeRecord, ModeReplay:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%s must be %q, %q or %q, got %q", ModeEnvVar, ModePassthrough, ModeRecord, ModeReplay, mode)
+	}
+}
+
+// ErrNoCassette is returned by New in replay mode when the cassette file does not exist
+var ErrNoCassette = errors.New("cassette not found")
+
+// Variable is an environment variable whose recorded value is saved in the cassette, so that a
+// replay configures resources exactly as the recording did. Secret values are replaced with a
+// placeholder in the cassette and everywhere they appear in recorded bodies.
+type Variable struct {
+	Name   string
+	Secret bool
+}
+
+// Cassette is the file format of recorded interactions
+type Cassette struct {
+	Variables    map[string]string `json:"variables,omitempty"`
+	Interactions []Interaction     `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response. Requests are identified by method and
+// request URI; the host is not recorded, so a replay can use any base URL with the same path.
+type Interaction struct {
+	Method       string            `json:"method"`
+	URI          string            `json:"uri"`
+	RequestBody  json.RawMessage   `json:"request_body,omitempty"`
+	Status       int               `json:"status"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	ResponseBody json.RawMessage   `json:"response_body,omitempty"`
+}
+
+// recordedHeaders are the response headers saved in cassettes
+var recordedHeaders = []string{"Content-Type", "Retry-After"}
+
+// Recorder is an http.RoundTripper that records interactions to a cassette or replays them. It is
+// safe for concurrent use.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+
+	// recorded secret value -> placeholder
+	secrets map[string]string
+}
+
+// New returns a recorder for the cassette at path. Recording captures the current values of
+// variables; replaying loads the cassette and fails with ErrNoCassette when it does not exist.
+func New(path string, mode Mode, variables []Variable) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: http.DefaultTransport,
+		cassette:  &Cassette{Variables: map[string]string{}},
+		secrets:   map[string]string{},
+	}
+
+	switch mode {
+	case ModePassthrough:
+	case ModeRecord:
+		for _, v := range variables {
+			value := os.Getenv(v.Name)
+			if v.Secret && value != "" {
+				placeholder := "REDACTED_" + v.Name
+				r.secrets[value] = placeholder
+				value = placeholder
+			}
+			r.cassette.Variables[v.Name] = value
+		}
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s: %w", path, ErrNoCassette)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %q", mode)
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Variables returns the variables saved in the cassette, with secret values replaced by placeholders
+func (r *Recorder) Variables() map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	variables := make(map[string]string, len(r.cassette.Variables))
+	for name, value := range r.cassette.Variables {
+		variables[name] = value
+	}
+	return variables
+}
+
+// Client returns an HTTP client that sends its requests through the recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r, Timeout: 30 * time.Second}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.mode {
+	case ModeRecord:
+		return r.record(req)
+	case ModeReplay:
+		return r.replay(req)
+	default:
+		return r.transport.RoundTrip(req)
+	}
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	interaction := Interaction{
+		Method:       req.Method,
+		URI:          req.URL.RequestURI(),
+		RequestBody:  r.scrub(requestBody),
+		Status:       resp.StatusCode,
+		ResponseBody: r.scrub(responseBody),
+	}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			if interaction.Headers == nil {
+				interaction.Headers = map[string]string{}
+			}
+			interaction.Headers[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay answers a request with the first unused interaction of the same method and request URI.
+// Once all of them are used, the last one is repeated, since polling may take fewer or more requests
+// than it did when recording.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	uri := req.URL.RequestURI()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Method != req.Method || interaction.URI != uri {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("cassette %s has no interaction for %s %s", r.path, req.Method, uri)
+	}
+	r.used[match] = true
+
+	interaction := r.cassette.Interactions[match]
+	header := http.Header{}
+	for name, value := range interaction.Headers {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(interaction.ResponseBody)),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// scrub returns a body as it is saved in a cassette: credential-like JSON values are masked as in
+// the client's logs, and the values of secret variables are replaced with their placeholders
+func (r *Recorder) scrub(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+
+	scrubbed := client.RedactBody(body)
+	for value, placeholder := range r.secrets {
+		scrubbed = strings.ReplaceAll(scrubbed, value, placeholder)
+		if encoded, err := json.Marshal(value); err == nil {
+			scrubbed = strings.ReplaceAll(scrubbed, strings.Trim(string(encoded), `"`), placeholder)
+		}
+	}
+
+	if !json.Valid([]byte(scrubbed)) {
+		encoded, _ := json.Marshal(scrubbed)
+		return encoded
+	}
+	return json.RawMessage(scrubbed)
+}
+
+// Stop saves the cassette when recording. Interactions are written in the order they were made.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// readBody reads and replaces a request or response body so it can still be read by its consumer
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
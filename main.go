@@ -1,14 +1,27 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
+	"github.com/sutrolabs/terraform-provider-census/census/clone"
+	"github.com/sutrolabs/terraform-provider-census/census/export"
 	"github.com/sutrolabs/terraform-provider-census/census/provider"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case export.Command:
+			os.Exit(export.Main(context.Background(), os.Args[2:], os.Stdout, os.Stderr))
+		case clone.Command:
+			os.Exit(clone.Main(context.Background(), os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
 	var debugMode bool
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()